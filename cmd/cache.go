@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect or clear the response cache",
+	Long: `Inspect or clear the on-disk cache of API responses.
+
+Successful responses are cached in --cache-dir, keyed by method, URL and payload, and
+served again while they are younger than the TTL of their endpoint: hours for daily
+data, an hour for the leaderboards and minutes for the vault listing and vault details.
+Set TTLs with --cache-ttl, e.g. --cache-ttl vaultDetails=30m,vaults=0.
+
+Every command accepts:
+
+  --no-cache   neither read nor write the cache
+  --refresh    fetch every response and refresh the cache with it
+  --offline    serve responses only from the cache, of any age; requests without a
+               cached response fail with exit code 7`,
+}
+
+// cacheStatsCmd represents the cache stats command
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show the number, size and age of the cached responses per endpoint",
+	Run: func(cmd *cobra.Command, args []string) {
+		stats, err := openCache().Stats()
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		render(view.CacheStats(stats))
+	},
+}
+
+// cacheClearCmd represents the cache clear command
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the cached responses",
+	Run: func(cmd *cobra.Command, args []string) {
+		expiredOnly, _ := cmd.Flags().GetBool("expired")
+
+		cache := openCache()
+		removed, err := cache.Clear(expiredOnly)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		fmt.Printf("Removed %d cached responses from %s\n", removed, cache.Dir())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheClearCmd.Flags().Bool("expired", false, "Only remove the responses older than the TTL of their endpoint")
+}
+
+// openCache opens the response cache selected by --cache-dir and --cache-ttl.
+func openCache() *hlstats.ResponseCache {
+	ttls, err := hlstats.ParseCacheTTLs(cfg.CacheTTLs)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	cache, err := hlstats.NewResponseCache(cfg.CacheDir, ttls)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	return cache
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+// newClient creates an API client configured from the global flags and config file, with the extra
+// options applied last.
+func newClient(extra ...hlstats.Option) *hlstats.Client {
+	limiter, err := newLimiter()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	weights, err := hlstats.ParseRequestWeights(cfg.RateLimitWeights)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	opts := []hlstats.Option{
+		hlstats.WithLimiter(limiter),
+		hlstats.WithRequestWeights(weights),
+		hlstats.WithRetryPolicy(hlstats.RetryPolicy{
+			MaxAttempts:       cfg.RetryMaxAttempts,
+			BaseDelay:         cfg.RetryBaseDelay,
+			MaxDelay:          cfg.RetryMaxDelay,
+			RetryableStatuses: cfg.RetryStatuses,
+		}),
+		hlstats.WithTimeout(cfg.Timeout),
+		hlstats.WithNetwork(cfg.Network),
+	}
+	if cfg.UserAgent != "" {
+		opts = append(opts, hlstats.WithUserAgent(cfg.UserAgent))
+	}
+	if cfg.NoCache && cfg.Offline {
+		log.Fatalf("Error: --no-cache and --offline cannot be used together")
+	}
+	if cfg.RecordDir != "" {
+		opts = append(opts, hlstats.WithRecorder(cfg.RecordDir))
+	}
+	if cfg.ReplayDir != "" {
+		// Recordings replace the API, so the cache is bypassed
+		opts = append(opts, hlstats.WithTransport(hlstats.NewReplayTransport(cfg.ReplayDir)))
+	} else if !cfg.NoCache {
+		cache, mode, err := newCache()
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		opts = append(opts, hlstats.WithCache(cache, mode))
+	}
+
+	opts = append(opts, extra...)
+
+	return hlstats.NewClient(cfg.BaseURL, cfg.InfoURL, opts...)
+}
+
+// newLimiter builds the client-side rate limiter from the rate limit flags. It returns nil if no window is set.
+func newLimiter() (hlstats.Limiter, error) {
+	var windows []hlstats.LimitWindow
+	for _, s := range cfg.RateLimits {
+		if s == "" {
+			continue
+		}
+		w, err := hlstats.ParseLimitWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	if cfg.RateLimitFile != "" {
+		return hlstats.NewFileLimiter(cfg.RateLimitFile, windows...)
+	}
+
+	return hlstats.NewLimiter(windows...), nil
+}
+
+// newCache opens the response cache and selects its mode from the cache flags.
+func newCache() (*hlstats.ResponseCache, hlstats.CacheMode, error) {
+	if cfg.Refresh && cfg.Offline {
+		return nil, 0, errors.New("--refresh and --offline cannot be used together")
+	}
+
+	mode := hlstats.CacheDefault
+	switch {
+	case cfg.Refresh:
+		mode = hlstats.CacheRefresh
+	case cfg.Offline:
+		mode = hlstats.CacheOffline
+	}
+
+	return openCache(), mode, nil
+}
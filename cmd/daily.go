@@ -8,7 +8,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/LampardNguyen234/hyperliquid-stats/internal/api"
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
 	"github.com/spf13/cobra"
 )
 
@@ -62,13 +63,15 @@ var dailyCmd = &cobra.Command{
 	Long: `Fetch and display daily USD volume data for a specific user.
     
 This command retrieves data from the daily_usd_volume_by_user endpoint
-and displays it in the specified format.`,
+and displays it in the specified format.
+The latest 25 days are shown by default; with --range, --from-date or --to-date every
+day of the range is shown unless --limit, --top or --bottom is given.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := api.NewClient(cfg.BaseURL, cfg.InfoURL)
+		client := newClient()
 
 		// Parse date flags if provided
 		var fromDate, toDate *time.Time
-		now := time.Now()
+		now := clock()
 
 		// Check for range flag
 		rangeFlag, _ := cmd.Flags().GetString("range")
@@ -117,9 +120,9 @@ and displays it in the specified format.`,
 		// Get user filter if provided
 		userFilter, _ := cmd.Flags().GetString("user")
 
-		items, err := client.FetchDailyVolumeByUser(fromDate, toDate, userFilter)
+		items, err := client.FetchDailyVolumeByUser(cmd.Context(), fromDate, toDate, userFilter)
 		if err != nil {
-			log.Fatalf("Error fetching daily volume for user: %v", err)
+			fatal(err, "Error fetching daily volume for user")
 		}
 
 		// Apply sorting
@@ -136,17 +139,15 @@ and displays it in the specified format.`,
 
 		items = items.SortByTime(sortDescending)
 
-		count, _ := cmd.Flags().GetInt("count")
-		if fromDate != nil || toDate != nil {
-			count = 0
-		}
-		fmt.Println(items.FormatString(count))
+		// A date range shows the whole range unless the page size is given explicitly
+		items = hlstats.Paginate(items, pageFromFlags(cmd, fromDate != nil || toDate != nil))
+		render(view.DailyVolumeByUsers(items))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(dailyCmd)
-	dailyCmd.Flags().IntP("count", "c", 25, "Number of daily volume entries to display")
+	addPageFlags(dailyCmd, 25, "daily volume entries")
 	dailyCmd.Flags().String("from-date", "", "Start date for filtering (YYYY-MM-DD format)")
 	dailyCmd.Flags().String("to-date", "", "End date for filtering (YYYY-MM-DD format)")
 	dailyCmd.Flags().StringP("range", "r", "", "Time range for filtering (e.g., 7D, 30D, 3M, 1Y)")
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRangeFlag(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rangeStr string
+		wantFrom time.Time
+		wantErr  bool
+	}{
+		{rangeStr: "7D", wantFrom: time.Date(2026, 3, 24, 12, 0, 0, 0, time.UTC)},
+		{rangeStr: " 2w ", wantFrom: time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{rangeStr: "1M", wantFrom: time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{rangeStr: "1Y", wantFrom: time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)},
+		{rangeStr: "7", wantErr: true},
+		{rangeStr: "7H", wantErr: true},
+		{rangeStr: "-1D", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rangeStr, func(t *testing.T) {
+			from, to, err := parseRangeFlag(tt.rangeStr, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRangeFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(now) {
+				t.Errorf("parseRangeFlag() = %v, %v, want %v, %v", from, to, tt.wantFrom, now)
+			}
+		})
+	}
+
+	if from, to, err := parseRangeFlag("", now); from != nil || to != nil || err != nil {
+		t.Errorf("parseRangeFlag(\"\") = %v, %v, %v, want no range", from, to, err)
+	}
+}
@@ -1,12 +1,12 @@
 package cmd
 
 import (
-	"fmt"
 	"log"
 	"strings"
 	"time"
 
-	"github.com/LampardNguyen234/hyperliquid-stats/internal/api"
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
 	"github.com/spf13/cobra"
 )
 
@@ -18,13 +18,15 @@ var dailyVolumeCmd = &cobra.Command{
 	Long: `Fetch and display daily USD volume data.
     
 This command retrieves data from the daily_usd_volume endpoint
-and displays it in the specified format.`,
+and displays it in the specified format.
+The latest 25 days are shown by default; with --range, --from-date or --to-date every
+day of the range is shown unless --limit, --top or --bottom is given.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := api.NewClient(cfg.BaseURL, cfg.InfoURL)
+		client := newClient()
 
 		// Parse date flags if provided
 		var fromDate, toDate *time.Time
-		now := time.Now()
+		now := clock()
 
 		// Check for range flag
 		rangeFlag, _ := cmd.Flags().GetString("range")
@@ -70,9 +72,9 @@ and displays it in the specified format.`,
 			}
 		}
 
-		items, err := client.FetchDailyVolume(fromDate, toDate)
+		items, err := client.FetchDailyVolume(cmd.Context(), fromDate, toDate)
 		if err != nil {
-			log.Fatalf("Error fetching daily volume: %v", err)
+			fatal(err, "Error fetching daily volume")
 		}
 
 		// Apply sorting
@@ -89,17 +91,15 @@ and displays it in the specified format.`,
 
 		items = items.SortByTime(sortDescending)
 
-		count, _ := cmd.Flags().GetInt("count")
-		if fromDate != nil || toDate != nil {
-			count = 0
-		}
-		fmt.Println(items.FormatString(count))
+		// A date range shows the whole range unless the page size is given explicitly
+		items = hlstats.Paginate(items, pageFromFlags(cmd, fromDate != nil || toDate != nil))
+		render(view.DailyVolumes(items))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(dailyVolumeCmd)
-	dailyVolumeCmd.Flags().IntP("count", "c", 25, "Number of daily volume entries to display")
+	addPageFlags(dailyVolumeCmd, 25, "daily volume entries")
 	dailyVolumeCmd.Flags().String("from-date", "", "Start date for filtering (YYYY-MM-DD format)")
 	dailyVolumeCmd.Flags().String("to-date", "", "End date for filtering (YYYY-MM-DD format)")
 	dailyVolumeCmd.Flags().StringP("range", "r", "", "Time range for filtering (e.g., 7D, 30D, 3M, 1Y)")
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the vaults at two points in time",
+	Long: `Compare the vaults snapshot taken at or before --from with the one taken at or before
+--to, or with the live vaults listing if --to is not given, and report:
+
+  - new vaults, vaults no longer listed and vaults that closed
+  - the largest absolute and relative TVL changes
+  - leader changes
+  - volume rank movers, if vault-volumes snapshots were taken at both points; for a live
+    comparison, --volumes fetches the volumes of the vaults of the --from snapshot
+
+Ranks only count the vaults present at both points, ranked by the --period volume of
+the --market. --from and --to take a date (YYYY-MM-DD, inclusive of the whole day) or a
+range before now (e.g. 7D). Use --limit to set the number of entries per table.
+Machine-readable formats carry one record per change.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		store := openExistingStore()
+		network := cfg.Network.Name
+
+		period, _ := cmd.Flags().GetString("period")
+		if _, err := (hlstats.MarketVolume{}).Period(period); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		marketFlag, _ := cmd.Flags().GetString("market")
+		market, err := hlstats.ParseMarket(marketFlag)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		fromValue, _ := cmd.Flags().GetString("from")
+		fromAt := parseUntil("from", fromValue)
+		from := latestSnapshot(store, network, snapshot.KindVaults, fromAt)
+
+		toValue, _ := cmd.Flags().GetString("to")
+		live := toValue == ""
+		var to snapshot.Snapshot
+		var toAt time.Time
+		progress, finishProgress := newProgress()
+		var client *hlstats.Client
+		if live {
+			client = newClient(hlstats.WithProgressReporter(progress))
+			vaults, err := client.FetchAllVault(cmd.Context())
+			if err != nil {
+				fatal(err, "Error fetching vaults")
+			}
+			to = snapshot.NewVaults(network, clock(), vaults)
+		} else {
+			toAt = parseUntil("to", toValue)
+			to = latestSnapshot(store, network, snapshot.KindVaults, toAt)
+			if !to.Time.After(from.Time) {
+				log.Fatalf("Error: --to selects the snapshot of %s, which is not after the --from snapshot of %s",
+					to.Time.Local().Format(time.DateTime), from.Time.Local().Format(time.DateTime))
+			}
+		}
+
+		minTVL, _ := cmd.Flags().GetFloat64("min-tvl")
+		diff := snapshot.CompareVaults(from, to, minTVL)
+
+		withVolumes, _ := cmd.Flags().GetBool("volumes")
+		interrupted := false
+		if live && withVolumes {
+			fromVolumes := latestSnapshot(store, network, snapshot.KindVaultVolumes, fromAt)
+			addresses := make([]string, 0, len(fromVolumes.Vaults))
+			for _, record := range fromVolumes.Vaults {
+				addresses = append(addresses, record.Address)
+			}
+
+			workers, _ := cmd.Flags().GetInt("workers")
+			volumes, err := client.FetchAllVaultVolumesConcurrent(cmd.Context(), false, hlstats.Page{}, workers, hlstats.AddressIn(addresses...))
+			finishProgress()
+			var failures hlstats.VaultFetchErrors
+			failures, interrupted = handleVaultFetchError(cmd, err, len(volumes), "Error fetching vault volumes")
+			toVolumes := snapshot.NewVaultVolumes(network, clock(), volumes, len(failures) > 0 || interrupted)
+			if err := diff.CompareVolumes(fromVolumes, toVolumes, period, market); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+		} else if !live {
+			fromVolumes, fromOK, err := store.Latest(network, snapshot.KindVaultVolumes, fromAt)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			toVolumes, toOK, err := store.Latest(network, snapshot.KindVaultVolumes, toAt)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			if fromOK && toOK && toVolumes.Time.After(fromVolumes.Time) {
+				if err := diff.CompareVolumes(fromVolumes, toVolumes, period, market); err != nil {
+					log.Fatalf("Error: %v", err)
+				}
+			} else if withVolumes {
+				log.Printf("Warning: no vault-volumes snapshots at both points; volume ranks are not compared")
+			}
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		render(view.VaultDiff(diff.Top(limit)))
+		if interrupted {
+			os.Exit(exitInterrupted)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().String("from", "", "Compare from the latest snapshot taken at or before a date (YYYY-MM-DD) or a range before now (e.g. 7D)")
+	diffCmd.Flags().String("to", "", "Compare to the latest snapshot taken at or before a date or range (live data if unset)")
+	diffCmd.Flags().Bool("volumes", false, "With live data, fetch the volumes of the vaults of the --from vault-volumes snapshot to compare volume ranks")
+	diffCmd.Flags().String("period", "week", "Volume period to rank vaults by: day, week, month, all-time")
+	diffCmd.Flags().String("market", string(hlstats.MarketAll), "Volumes to rank vaults by: all, perp, spot")
+	diffCmd.Flags().Float64("min-tvl", 10000, "Leave vaults with a TVL below this at both points out of the TVL changes")
+	diffCmd.Flags().IntP("limit", "l", 10, "Number of entries per table (0 for all)")
+	diffCmd.Flags().IntP("workers", "w", 5, "Number of concurrent workers for fetching vault volumes")
+	diffCmd.Flags().Bool("fail-on-partial", false, "Exit with an error if any vault fails to fetch")
+	diffCmd.Flags().Int("max-failures", -1, "Exit with an error if more than this many vaults fail to fetch (-1 for no limit)")
+	_ = diffCmd.MarkFlagRequired("from")
+}
+
+// latestSnapshot returns the latest snapshot of a kind taken at or before at, and exits if there is
+// none. A nil store, as returned by openExistingStore, holds no snapshot.
+func latestSnapshot(store *snapshot.Store, network string, kind snapshot.Kind, at time.Time) snapshot.Snapshot {
+	var snap snapshot.Snapshot
+	var ok bool
+	var err error
+	if store != nil {
+		snap, ok, err = store.Latest(network, kind, at)
+	}
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	if !ok {
+		log.Fatalf("Error: no %s snapshot of %s taken at or before %s in %s (see the snapshot command)",
+			kind, network, at.Local().Format(time.DateTime), cfg.StoreDir)
+	}
+
+	return snap
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+// Process exit codes, so that scripts can tell failure classes apart.
+const (
+	exitError          = 1
+	exitAPIError       = 3
+	exitRateLimited    = 4
+	exitDecodeError    = 5
+	exitPartialFailure = 6
+	exitCacheMiss      = 7
+	exitInterrupted    = 130
+)
+
+// exitCode maps err to the process exit code.
+func exitCode(err error) int {
+	var statusErr *hlstats.StatusError
+	var decodeErr *hlstats.DecodeError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, hlstats.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, hlstats.ErrCacheMiss):
+		return exitCacheMiss
+	case errors.As(err, &statusErr):
+		return exitAPIError
+	case errors.As(err, &decodeErr):
+		return exitDecodeError
+	default:
+		return exitError
+	}
+}
+
+// fatal logs msg followed by err and exits with the exit code matching err.
+func fatal(err error, msg string) {
+	log.Printf("%s: %v", msg, err)
+	os.Exit(exitCode(err))
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "generic", err: errors.New("boom"), want: exitError},
+		{name: "interrupted", err: errors.Wrap(context.Canceled, "fetch"), want: exitInterrupted},
+		{name: "rate limited", err: errors.Wrap(hlstats.ErrRateLimited, "fetch"), want: exitRateLimited},
+		{name: "cache miss", err: errors.Wrap(hlstats.ErrCacheMiss, "fetch"), want: exitCacheMiss},
+		{name: "status", err: errors.Wrap(&hlstats.StatusError{StatusCode: 500}, "fetch"), want: exitAPIError},
+		{name: "decode", err: errors.Wrap(&hlstats.DecodeError{Err: errors.New("bad")}, "fetch"), want: exitDecodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
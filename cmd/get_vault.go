@@ -1,10 +1,8 @@
 package cmd
 
 import (
-	"fmt"
-	"log"
-
-	"github.com/LampardNguyen234/hyperliquid-stats/internal/api"
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
 	"github.com/spf13/cobra"
 )
 
@@ -15,36 +13,47 @@ var getVaultCmd = &cobra.Command{
 	Short:   "Fetch open vaults with HLP priority",
 	Long: `Fetch and display open vaults with HLP vaults shown first.
     
-This command retrieves only open vault data, showing HLP vaults first,
+This command retrieves open vault data, showing HLP vaults first,
 then other vaults, with TVL sorting within each category (descending by default).
-Vaults with TVL below the minimum threshold (default: 50,000) are filtered out.`,
+Use --sort-by to sort by other keys, e.g. --sort-by apr, --sort-by age or
+--sort-by hlp:desc,tvl:desc,name:asc; HLP vaults stay first unless hlp is a key.
+Vaults with TVL below the minimum threshold (default: 50,000) are filtered out.
+Use --include-closed, --max-tvl, --leader, --name-regex, --created-after,
+--min-apr and --max-apr to refine the listing; all filters must match.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := api.NewClient(cfg.BaseURL, cfg.InfoURL)
+		client := newClient()
+
+		// Validate the sorting before fetching
+		keys := sortKeys(cmd, hlstats.VaultSortFields, true)
+		if cmd.Flags().Changed("desc") {
+			sortDesc, _ := cmd.Flags().GetBool("desc")
+			order := hlstats.SortAsc
+			if sortDesc {
+				order = hlstats.SortDesc
+			}
+			keys = keys.WithDefaultOrder(order)
+		}
 
-		items, err := client.FetchAllVault()
+		items, err := client.FetchAllVault(cmd.Context())
 		if err != nil {
-			log.Fatalf("Error fetching vaults: %v", err)
+			fatal(err, "Error fetching vaults")
 		}
 
-		// Always filter to show only open vaults
-		items = items.FilterOpenVaults()
-
-		// Apply TVL threshold filtering
-		minTVL, _ := cmd.Flags().GetFloat64("min-tvl")
-		items = items.FilterByMinTVL(minTVL)
+		// Apply the listing filters (open vaults above the TVL threshold by default)
+		items = items.Filter(vaultFilters(cmd)...)
 
-		// Apply HLP prioritization with TVL sorting within each category
-		sortDesc, _ := cmd.Flags().GetBool("desc")
-		items = items.SortWithHLPPriority(!sortDesc)
+		// Apply HLP prioritization with sorting within each category
+		items, _ = items.SortBy(keys)
 
-		count, _ := cmd.Flags().GetInt("count")
-		fmt.Println(items.FormatString(count))
+		items = hlstats.Paginate(items, pageFromFlags(cmd, false))
+		render(view.Vaults(items))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(getVaultCmd)
-	getVaultCmd.Flags().IntP("count", "c", 100, "Number of vaults to display")
-	getVaultCmd.Flags().Bool("desc", true, "Sort TVL in descending order (within HLP/non-HLP categories)")
-	getVaultCmd.Flags().Float64("min-tvl", 50000, "Minimum TVL threshold for vault filtering")
+	addPageFlags(getVaultCmd, 100, "vaults")
+	getVaultCmd.Flags().Bool("desc", true, "Sort keys without :asc or :desc in descending order (default: numbers descending, text ascending)")
+	getVaultCmd.Flags().String("sort-by", "tvl", "Sort keys, "+sortKeysHelp+": tvl, apr, age, name, address, leader, hlp (HLP vaults first unless hlp is a key)")
+	addVaultFilterFlags(getVaultCmd, 50000)
 }
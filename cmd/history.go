@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"log"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Query the series of a vault or user from the snapshot store",
+	Long: `Query the local snapshot store recorded by the snapshot command. No request is made
+to the API.
+
+  --vault <query>   TVL and APR of a vault from vaults snapshots, and its volumes from
+                    vault-volumes snapshots; the query is resolved against the latest
+                    vaults snapshot (see vault-search)
+  --user <address>  ranks, volume and trade count of a user on the leaderboards
+
+Without --vault or --user, the stored snapshots of the selected network are listed.
+Use --since and --until to restrict the time range.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		store := openExistingStore()
+		network := cfg.Network.Name
+
+		var since, until time.Time
+		if value, _ := cmd.Flags().GetString("since"); value != "" {
+			since = parseSince("since", value)
+		}
+		if value, _ := cmd.Flags().GetString("until"); value != "" {
+			until = parseUntil("until", value)
+		}
+
+		vaultQuery, _ := cmd.Flags().GetString("vault")
+		user, _ := cmd.Flags().GetString("user")
+		if store == nil {
+			// A missing store holds no snapshot and is not created
+			log.Printf("No snapshots in %s (see the snapshot command)", cfg.StoreDir)
+			switch {
+			case vaultQuery != "":
+				render(view.VaultHistory(nil))
+			case user != "":
+				render(view.UserHistory(nil))
+			default:
+				render(view.SnapshotInfos(nil))
+			}
+			return
+		}
+
+		switch {
+		case vaultQuery != "":
+			vault := resolveStoredVault(store, network, vaultQuery)
+			points, err := store.VaultHistory(network, vault.Data.Address, since, until)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			if len(points) == 0 {
+				log.Printf("No snapshot of vault %s in %s", vault.Data.Address, store.Dir())
+			}
+			render(view.VaultHistory(points))
+
+		case user != "":
+			points, err := store.UserHistory(network, user, since, until)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			if len(points) == 0 {
+				log.Printf("No snapshot ranks user %s in %s", user, store.Dir())
+			}
+			render(view.UserHistory(points))
+
+		default:
+			infos, err := store.List(network)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			var selected []snapshot.Info
+			for _, info := range infos {
+				if (since.IsZero() || !info.Time.Before(since)) && (until.IsZero() || !info.Time.After(until)) {
+					selected = append(selected, info)
+				}
+			}
+			render(view.SnapshotInfos(selected))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().String("vault", "", "Vault to show the history of: "+vaultQueryHelp)
+	historyCmd.Flags().String("user", "", "User address to show the leaderboard history of")
+	historyCmd.Flags().String("since", "", "Only snapshots taken after a date (YYYY-MM-DD) or within a range (e.g. 30D, 6M, 1Y)")
+	historyCmd.Flags().String("until", "", "Only snapshots taken up to a date (YYYY-MM-DD) or up to a range before now (e.g. 7D)")
+	historyCmd.MarkFlagsMutuallyExclusive("vault", "user")
+}
+
+// resolveStoredVault resolves a vault query against the latest vaults snapshot of a network. A full
+// address resolves even if no snapshot lists it.
+func resolveStoredVault(store *snapshot.Store, network, query string) hlstats.Vault {
+	latest, _, err := store.Latest(network, snapshot.KindVaults, time.Time{})
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	vault, err := latest.ListedVaults().Resolve(query)
+	return checkResolvedVault(query, vault, err)
+}
@@ -1,10 +1,11 @@
 package cmd
 
 import (
-	"fmt"
 	"log"
+	"time"
 
-	"github.com/LampardNguyen234/hyperliquid-stats/internal/api"
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
 	"github.com/spf13/cobra"
 )
 
@@ -16,22 +17,75 @@ var largestCmd = &cobra.Command{
 	Long: `Fetch and display the largest users by USD volume.
     
 This command retrieves data from the largest_users_by_usd_volume endpoint
-and displays it in the specified format.`,
+and displays it in the specified format, with the rank of every user.
+` + leaderboardHelp,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := api.NewClient(cfg.BaseURL, cfg.InfoURL)
+		client := newClient()
 
-		items, err := client.FetchLargestUsers()
+		items, err := client.FetchLargestUsers(cmd.Context())
 		if err != nil {
-			log.Fatalf("Error fetching largest users: %v", err)
+			fatal(err, "Error fetching largest users")
 		}
 
-		count, _ := cmd.Flags().GetInt("count")
-		fmt.Println(items.FormatString(count))
+		renderLeaderboard(cmd, snapshot.NewLargestUsers(client.Network().Name, clock(), items))
 	},
 }
 
+// leaderboardHelp describes the comparison of leaderboards with stored snapshots.
+const leaderboardHelp = `
+If the snapshot store holds a snapshot of the leaderboard (see the snapshot command),
+the leaderboard is compared with the latest one, or with the one taken nearest to
+--since (e.g. --since 7D): the rank and value changes are shown, new users are marked
+NEW and the users that dropped out of the selected page are listed after it.`
+
 func init() {
 	rootCmd.AddCommand(largestCmd)
 
-	largestCmd.Flags().IntP("count", "c", 25, "Number of largest users to display")
+	addPageFlags(largestCmd, 25, "largest users")
+	addLeaderboardFlags(largestCmd)
+}
+
+// addLeaderboardFlags registers the flags of renderLeaderboard on cmd.
+func addLeaderboardFlags(cmd *cobra.Command) {
+	cmd.Flags().String("since", "", "Compare with the snapshot taken nearest to a date (YYYY-MM-DD) or range before now (e.g. 7D)")
+}
+
+// renderLeaderboard renders the page of a leaderboard selected by the page flags, compared with
+// the stored snapshot selected by --since or, without it, with the latest stored snapshot if any.
+// A missing snapshot store holds no snapshot and is not created.
+func renderLeaderboard(cmd *cobra.Command, current snapshot.Snapshot) {
+	store := openExistingStore()
+
+	var previous snapshot.Snapshot
+	var ok bool
+	var err error
+	if value, _ := cmd.Flags().GetString("since"); value != "" {
+		at := parseSince("since", value)
+		if store != nil {
+			previous, ok, err = store.Nearest(current.Network, current.Kind, at)
+		}
+		if err == nil && !ok {
+			log.Fatalf("Error: no %s snapshot of %s in %s to compare with (see the snapshot command)",
+				current.Kind, current.Network, cfg.StoreDir)
+		}
+	} else if store != nil {
+		previous, ok, err = store.Latest(current.Network, current.Kind, current.Time)
+	}
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	users := snapshot.Ranks(current)
+	var since time.Time
+	if ok {
+		users = snapshot.CompareRanks(previous, current)
+		since = previous.Time
+	}
+	users = users.Paginate(pageFromFlags(cmd, false))
+
+	if isTableFormat() {
+		render(view.LeaderboardReport{Kind: current.Kind, Since: since, Users: view.RankedUsers(users)})
+		return
+	}
+	render(view.RankedUsers(users))
 }
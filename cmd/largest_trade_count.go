@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-	"log"
-
-	"github.com/LampardNguyen234/hyperliquid-stats/internal/api"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
 	"github.com/spf13/cobra"
 )
 
@@ -16,21 +13,22 @@ var largestTradeCountCmd = &cobra.Command{
 	Long: `Fetch and display the largest users by trade count.
     
 This command retrieves data from the largest_users_by_trade_count endpoint
-and displays it in the specified format.`,
+and displays it in the specified format, with the rank of every user.
+` + leaderboardHelp,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := api.NewClient(cfg.BaseURL, cfg.InfoURL)
+		client := newClient()
 
-		items, err := client.FetchLargestTradeCounts()
+		items, err := client.FetchLargestTradeCounts(cmd.Context())
 		if err != nil {
-			log.Fatalf("Error fetching largest trade counts: %v", err)
+			fatal(err, "Error fetching largest trade counts")
 		}
 
-		count, _ := cmd.Flags().GetInt("count")
-		fmt.Println(items.FormatString(count))
+		renderLeaderboard(cmd, snapshot.NewTradeCounts(client.Network().Name, clock(), items))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(largestTradeCountCmd)
-	largestTradeCountCmd.Flags().IntP("count", "c", 25, "Number of largest trade count users to display")
+	addPageFlags(largestTradeCountCmd, 25, "largest trade count users")
+	addLeaderboardFlags(largestTradeCountCmd)
 }
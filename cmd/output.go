@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+)
+
+// outputFormat returns the output format selected by the --format flag or config file.
+func outputFormat() common.Format {
+	format, err := common.ParseFormat(cfg.Format)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	return format
+}
+
+// isTableFormat reports whether the human-readable table output is selected.
+func isTableFormat() bool {
+	return outputFormat() == common.FormatTable
+}
+
+// render prints r to stdout in the selected output format.
+func render(r common.Renderer) {
+	if err := common.Render(os.Stdout, outputFormat(), r); err != nil {
+		log.Fatalf("Error rendering output: %v", err)
+	}
+}
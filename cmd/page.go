@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// pageFlags are the flags that set the size of a page.
+var pageFlags = []string{"limit", "count", "top", "bottom"}
+
+// addPageFlags registers the pagination flags on cmd, with limit as the default of --limit (0 for
+// all). what names the listed items in the flag descriptions.
+func addPageFlags(cmd *cobra.Command, limit int, what string) {
+	cmd.Flags().IntP("limit", "l", limit, "Maximum number of "+what+" to display (0 for all)")
+	cmd.Flags().Int("offset", 0, "Number of "+what+" to skip, from the end with --bottom")
+	cmd.Flags().Int("top", 0, "Display the first N "+what+" (same as --limit)")
+	cmd.Flags().Int("bottom", 0, "Display the last N "+what)
+	cmd.Flags().IntP("count", "c", limit, "Maximum number of "+what+" to display (0 for all)")
+	_ = cmd.Flags().MarkDeprecated("count", "use --limit instead")
+	cmd.MarkFlagsMutuallyExclusive(pageFlags...)
+}
+
+// pageFromFlags returns the page selected by the flags registered with addPageFlags. If allByDefault
+// is set, every item is selected unless the size of the page is given explicitly.
+func pageFromFlags(cmd *cobra.Command, allByDefault bool) hlstats.Page {
+	flags := cmd.Flags()
+
+	var page hlstats.Page
+	page.Offset, _ = flags.GetInt("offset")
+	switch {
+	case flags.Changed("bottom"):
+		page.Bottom = true
+		page.Limit, _ = flags.GetInt("bottom")
+	case flags.Changed("top"):
+		page.Limit, _ = flags.GetInt("top")
+	case flags.Changed("count"):
+		page.Limit, _ = flags.GetInt("count")
+	case flags.Changed("limit") || !allByDefault:
+		page.Limit, _ = flags.GetInt("limit")
+	}
+
+	if err := page.Validate(); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	return page
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/mattn/go-isatty"
+)
+
+const progressBarWidth = 30
+
+// progressBar renders vault fetch progress as a single, continuously redrawn line.
+type progressBar struct {
+	mu     sync.Mutex
+	w      io.Writer
+	failed int
+	drawn  bool
+}
+
+func (p *progressBar) OnProgress(event hlstats.ProgressEvent) {
+	if event.Type != hlstats.VaultDone && event.Type != hlstats.VaultFailed {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if event.Type == hlstats.VaultFailed {
+		p.failed++
+	}
+
+	filled := 0
+	if event.Total > 0 {
+		filled = event.Completed * progressBarWidth / event.Total
+	}
+	fmt.Fprintf(p.w, "\r[%s%s] %d/%d vaults, %d failed, %s",
+		strings.Repeat("#", filled),
+		strings.Repeat("-", progressBarWidth-filled),
+		event.Completed, event.Total, p.failed,
+		event.Elapsed.Round(100*time.Millisecond),
+	)
+	p.drawn = true
+}
+
+// finish terminates the progress line so that subsequent output starts on a new line.
+func (p *progressBar) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.drawn {
+		fmt.Fprintln(p.w)
+	}
+}
+
+// progressLogger writes one line per progress event.
+type progressLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (p *progressLogger) OnProgress(event hlstats.ProgressEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	line := fmt.Sprintf("%-7s vault: %v, attempt: %d, timeElapsed: %v",
+		strings.ToUpper(string(event.Type)), event.Address, event.Attempt, event.Elapsed)
+	if event.Completed > 0 {
+		line += fmt.Sprintf(", count: %d/%d", event.Completed, event.Total)
+	}
+	if event.Err != nil {
+		line += fmt.Sprintf(", error: %v", event.Err)
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// newProgress returns the progress reporter for a client, chosen according to the --quiet and
+// --verbose flags, or nil for none. Progress is always written to stderr; the bar is only shown when
+// stderr is a terminal. The returned function must be called once fetching is complete.
+func newProgress() (hlstats.ProgressReporter, func()) {
+	switch {
+	case cfg.Quiet:
+		return nil, func() {}
+	case cfg.Verbose:
+		return &progressLogger{w: os.Stderr}, func() {}
+	case isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd()):
+		bar := &progressBar{w: os.Stderr}
+		return bar, bar.finish
+	default:
+		return nil, func() {}
+	}
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/LampardNguyen234/hyperliquid-stats/internal/config"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -12,6 +18,9 @@ import (
 var (
 	cfgFile string
 	cfg     *config.Config
+
+	// clock returns the current time, from which relative date ranges are derived. Tests pin it.
+	clock = time.Now
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -22,13 +31,17 @@ var rootCmd = &cobra.Command{
 	Long: `Hyperliquid Stats is a command-line tool for fetching and analyzing
 cryptocurrency volume data from various endpoints.
 
-It provides multiple output formats (table, JSON, CSV) and supports
+It provides multiple output formats (table, JSON, CSV, NDJSON) and supports
 multiple data sources for comprehensive volume analysis.`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// The command context is cancelled on SIGINT/SIGTERM so that in-flight requests are aborted.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
@@ -39,12 +52,63 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.hype-stats.yaml)")
-	rootCmd.PersistentFlags().StringP("base-url", "b", config.DefaultBaseURL, "Base URL for the API")
-	rootCmd.PersistentFlags().StringP("info-url", "i", config.DefaultInfoURL, "Info URL for the API")
+	rootCmd.PersistentFlags().StringP("network", "n", config.DefaultNetwork, "Network profile: mainnet, testnet or a profile from the config file")
+	rootCmd.PersistentFlags().StringP("base-url", "b", "", "Base URL for the API (default: from --network)")
+	rootCmd.PersistentFlags().StringP("info-url", "i", "", "Info URL for the API (default: from --network)")
+	rootCmd.PersistentFlags().String("vaults-url", "", "Vaults listing URL (default: from --network)")
+	rootCmd.PersistentFlags().StringP("format", "f", config.DefaultFormat, "Output format: table, json, csv, ndjson")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress progress output")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Log every progress event to stderr")
+	rootCmd.PersistentFlags().Duration("timeout", config.DefaultTimeout, "Timeout of every single HTTP attempt (0 for none)")
+	rootCmd.PersistentFlags().String("user-agent", hlstats.DefaultUserAgent, "User-Agent header sent with every request")
+
+	retryPolicy := hlstats.DefaultRetryPolicy()
+	rootCmd.PersistentFlags().Int("retry-max-attempts", retryPolicy.MaxAttempts, "Maximum number of attempts per HTTP request (1 disables retries)")
+	rootCmd.PersistentFlags().Duration("retry-base-delay", retryPolicy.BaseDelay, "Backoff before the first retry, doubled on every further retry")
+	rootCmd.PersistentFlags().Duration("retry-max-delay", retryPolicy.MaxDelay, "Maximum backoff between retries, including Retry-After delays")
+	rootCmd.PersistentFlags().IntSlice("retry-statuses", retryPolicy.RetryableStatuses, "HTTP status codes that are retried")
+
+	var rateLimits []string
+	for _, w := range hlstats.DefaultLimitWindows() {
+		rateLimits = append(rateLimits, w.String())
+	}
+	rootCmd.PersistentFlags().StringSlice("rate-limit", rateLimits, "Client-side rate limit windows as <weight>/<interval> (empty to disable)")
+	rootCmd.PersistentFlags().StringSlice("rate-limit-weight", nil, "Request weights as <type>=<weight>, e.g. vaultDetails=20 or get=1 (default weight: 1)")
+	rootCmd.PersistentFlags().String("rate-limit-file", "", "Share the rate limit budget with other processes through this file")
+	rootCmd.PersistentFlags().String("store-dir", "", "Directory of the snapshot store (default is $HOME/"+config.DefaultStoreDir+")")
+	rootCmd.PersistentFlags().String("cache-dir", "", "Directory of the response cache (default is $HOME/"+config.DefaultCacheDir+")")
+	rootCmd.PersistentFlags().StringSlice("cache-ttl", nil, "Cache TTLs as <endpoint>=<duration>, e.g. vaultDetails=10m or vaults=0 to disable")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Neither read nor write the response cache")
+	rootCmd.PersistentFlags().Bool("refresh", false, "Fetch every response and refresh the cache with it")
+	rootCmd.PersistentFlags().Bool("offline", false, "Serve responses only from the cache, of any age, and fail on misses")
+	rootCmd.PersistentFlags().String("record-dir", "", "Record every response to a golden file in this directory")
+	rootCmd.PersistentFlags().String("replay-dir", "", "Serve the responses recorded with --record-dir in this directory instead of sending requests")
 
 	// Bind flags to viper
+	viper.BindPFlag("network", rootCmd.PersistentFlags().Lookup("network"))
 	viper.BindPFlag("base_url", rootCmd.PersistentFlags().Lookup("base-url"))
+	viper.BindPFlag("vaults_url", rootCmd.PersistentFlags().Lookup("vaults-url"))
 	viper.BindPFlag("info_url", rootCmd.PersistentFlags().Lookup("info-url"))
+	viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
+	viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet"))
+	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("user_agent", rootCmd.PersistentFlags().Lookup("user-agent"))
+	viper.BindPFlag("retry_max_attempts", rootCmd.PersistentFlags().Lookup("retry-max-attempts"))
+	viper.BindPFlag("retry_base_delay", rootCmd.PersistentFlags().Lookup("retry-base-delay"))
+	viper.BindPFlag("retry_max_delay", rootCmd.PersistentFlags().Lookup("retry-max-delay"))
+	viper.BindPFlag("retry_statuses", rootCmd.PersistentFlags().Lookup("retry-statuses"))
+	viper.BindPFlag("rate_limits", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	viper.BindPFlag("rate_limit_weights", rootCmd.PersistentFlags().Lookup("rate-limit-weight"))
+	viper.BindPFlag("rate_limit_file", rootCmd.PersistentFlags().Lookup("rate-limit-file"))
+	viper.BindPFlag("store_dir", rootCmd.PersistentFlags().Lookup("store-dir"))
+	viper.BindPFlag("cache_dir", rootCmd.PersistentFlags().Lookup("cache-dir"))
+	viper.BindPFlag("cache_ttls", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	viper.BindPFlag("no_cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	viper.BindPFlag("refresh", rootCmd.PersistentFlags().Lookup("refresh"))
+	viper.BindPFlag("offline", rootCmd.PersistentFlags().Lookup("offline"))
+	viper.BindPFlag("record_dir", rootCmd.PersistentFlags().Lookup("record-dir"))
+	viper.BindPFlag("replay_dir", rootCmd.PersistentFlags().Lookup("replay-dir"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -68,5 +132,10 @@ func initConfig() {
 	}
 
 	// Initialize config
-	cfg = config.New()
+	var err error
+	cfg, err = config.New()
+	cobra.CheckErr(err)
+
+	_, err = common.ParseFormat(cfg.Format)
+	cobra.CheckErr(err)
 }
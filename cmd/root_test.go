@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// testNow is the current time of the tests, within the days of the recordings.
+var testNow = time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	// Times are shown in local time and ranges relative to now match the recorded requests
+	time.Local = time.UTC
+	clock = func() time.Time { return testNow }
+	os.Exit(m.Run())
+}
+
+// replayArgs serve every request from the synthetic fixtures in testdata/recordings, which were
+// recorded with --record-dir from testdata/fakeapi. The URLs point nowhere, so that no request can
+// reach the network.
+var replayArgs = []string{
+	"-q",
+	"--replay-dir", filepath.Join("testdata", "recordings"),
+	"-b", "http://replay.invalid",
+	"--info-url", "http://replay.invalid/info",
+	"--vaults-url", "http://replay.invalid/vaults",
+}
+
+// resetFlags restores every flag of cmd and its subcommands to its default, as flags keep their
+// values between executions of the same command tree.
+func resetFlags(t *testing.T, cmd *cobra.Command) {
+	t.Helper()
+	reset := func(f *pflag.Flag) {
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(f.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			if err := v.Replace(values); err != nil {
+				t.Fatalf("reset --%s: %v", f.Name, err)
+			}
+		} else if err := f.Value.Set(f.DefValue); err != nil {
+			t.Fatalf("reset --%s: %v", f.Name, err)
+		}
+		f.Changed = false
+	}
+
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(t, c)
+	}
+}
+
+// execute runs the CLI with args against the recordings, with a fresh home and snapshot store
+// directory, and returns what it wrote to stdout. Requests without a recording fail the test, even
+// when the command only logs them as a warning.
+func execute(t *testing.T, storeDir string, args ...string) []byte {
+	t.Helper()
+	resetFlags(t, rootCmd)
+	t.Setenv("HOME", t.TempDir())
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	out := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- data
+	}()
+
+	rootCmd.SetArgs(append(append([]string{"--store-dir", storeDir}, replayArgs...), args...))
+	err = rootCmd.ExecuteContext(context.Background())
+	w.Close()
+	got := <-out
+	if err != nil {
+		t.Fatalf("execute %v: %v", args, err)
+	}
+	if strings.Contains(logs.String(), "no recorded response") {
+		t.Fatalf("execute %v sent a request that was not recorded:\n%s", args, logs.String())
+	}
+
+	return got
+}
+
+// checkGolden compares got with the golden file testdata/golden/name, or writes it with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run go test -update to accept it):\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "largest-volume", args: []string{"largest-volume"}},
+		{name: "largest-volume-csv", args: []string{"largest-volume", "-f", "csv", "--limit", "2"}},
+		{name: "largest-trade-count", args: []string{"largest-trade-count"}},
+		{name: "daily-volume", args: []string{"daily-volume", "--from-date", "2026-10-01", "--to-date", "2026-10-10"}},
+		{name: "daily-volume-by-user", args: []string{"daily-volume-by-user", "--from-date", "2026-10-01", "--to-date", "2026-10-05"}},
+		{name: "get-vault-csv", args: []string{"get-vault", "-f", "csv"}},
+		{name: "vault-volume", args: []string{"vault-volume"}},
+		{name: "vault-volume-address", args: []string{"vault-volume", "--address", "0xa1"}},
+		{name: "vault-volume-summary", args: []string{"vault-volume", "--summary"}},
+		{name: "vault-volume-perp-csv", args: []string{"vault-volume", "--market", "perp", "--sort-by", "week", "-f", "csv"}},
+		{name: "vault-volume-spot-json", args: []string{"vault-volume", "--market", "spot", "--limit", "2", "-f", "json"}},
+		{name: "vault-details", args: []string{"vault-details", "--address", "0xa1"}},
+		{name: "vault-performance", args: []string{"vault-performance"}},
+		{name: "vault-performance-hlp", args: []string{"vault-performance", "--hlp", "--sort-by", "return"}},
+		{name: "vault-followers", args: []string{"vault-followers", "--address", "0xa1"}},
+		{name: "vault-tree", args: []string{"vault-tree"}},
+		{name: "vault-tree-csv", args: []string{"vault-tree", "-f", "csv"}},
+		{name: "vault-search", args: []string{"vault-search", "alpha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := execute(t, t.TempDir(), tt.args...)
+			checkGolden(t, tt.name+".golden", got)
+		})
+	}
+}
+
+// readCSV parses the CSV output of a command into records keyed by the header.
+func readCSV(t *testing.T, data []byte) []map[string]string {
+	t.Helper()
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil || len(rows) == 0 {
+		t.Fatalf("invalid CSV output %q: %v", data, err)
+	}
+
+	var records []map[string]string
+	for _, row := range rows[1:] {
+		record := make(map[string]string)
+		for i, column := range rows[0] {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSnapshotFlow(t *testing.T) {
+	storeDir := t.TempDir()
+	execute(t, storeDir, "snapshot")
+
+	snapshots := readCSV(t, execute(t, storeDir, "history", "-f", "csv"))
+	kinds := make(map[string]string)
+	for _, s := range snapshots {
+		if s["partial"] != "false" {
+			t.Errorf("snapshot %s is partial", s["kind"])
+		}
+		kinds[s["kind"]] = s["records"]
+	}
+	if want := map[string]string{"vaults": "6", "largest-users": "4", "trade-counts": "3"}; !reflect.DeepEqual(kinds, want) {
+		t.Errorf("history kinds and records = %v, want %v", kinds, want)
+	}
+
+	// The leaderboard is ranked against the snapshot just taken
+	users := readCSV(t, execute(t, storeDir, "largest-volume", "-f", "csv"))
+	if len(users) == 0 {
+		t.Fatalf("largest-volume returned no users")
+	}
+	for _, u := range users {
+		if u["previous_rank"] == "" || u["previous_rank"] != u["rank"] {
+			t.Errorf("user %s: rank %s, previous rank %q, want unchanged", u["user"], u["rank"], u["previous_rank"])
+		}
+	}
+
+	// The latest snapshot before a future date is the one just taken, so nothing changed since
+	if got := readCSV(t, execute(t, storeDir, "diff", "--from", "2100-01-01", "-f", "csv")); len(got) != 0 {
+		t.Errorf("diff --from 2100-01-01 = %v, want no changes", got)
+	}
+}
+
+func TestLeaderboardWithoutStore(t *testing.T) {
+	storeDir := filepath.Join(t.TempDir(), "snapshots")
+
+	users := readCSV(t, execute(t, storeDir, "largest-volume", "-f", "csv"))
+	if len(users) == 0 || users[0]["previous_rank"] != "" {
+		t.Errorf("largest-volume without a store = %v, want users without previous ranks", users)
+	}
+	if _, err := os.Stat(storeDir); !os.IsNotExist(err) {
+		t.Errorf("largest-volume created the snapshot store: %v", err)
+	}
+}
+
+func TestHistoryWithoutStore(t *testing.T) {
+	storeDir := filepath.Join(t.TempDir(), "snapshots")
+
+	if got := readCSV(t, execute(t, storeDir, "history", "-f", "csv")); len(got) != 0 {
+		t.Errorf("history without a store = %v, want no snapshots", got)
+	}
+	if _, err := os.Stat(storeDir); !os.IsNotExist(err) {
+		t.Errorf("history created the snapshot store: %v", err)
+	}
+}
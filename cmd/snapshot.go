@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// defaultSnapshotKinds are recorded by the snapshot command when no kind is given. Vault volumes
+// take one request per vault and are only recorded on request.
+var defaultSnapshotKinds = []snapshot.Kind{snapshot.KindVaults, snapshot.KindLargestUsers, snapshot.KindTradeCounts}
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot [kind...]",
+	Short: "Record timestamped results in the local snapshot store",
+	Long: `Fetch data and append it, timestamped, to the local snapshot store, so that its
+evolution can be queried later with the history command.
+
+Kinds:
+
+  vaults          the vaults listing: TVL, APR, status and relationship of every vault
+  vault-volumes   the volumes of the vaults selected by the listing filters, --hlp and the
+                  pagination flags (one request per vault)
+  largest-users   the leaderboard by USD volume
+  trade-counts    the leaderboard by trade count
+
+Without arguments, vaults, largest-users and trade-counts are recorded; the leaderboards
+are skipped on networks without a stats endpoint. All kinds recorded by one run share
+the same timestamp. The store is a directory of NDJSON files, one per network and
+kind, set with --store-dir. Run the command periodically, e.g. from cron, to build
+up a history.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		progress, finishProgress := newProgress()
+		client := newClient(hlstats.WithProgressReporter(progress))
+		store := openStore()
+		network := client.Network().Name
+
+		kinds := defaultSnapshotKinds
+		explicit := len(args) > 0
+		if explicit {
+			kinds = nil
+			for _, arg := range args {
+				kind, err := snapshot.ParseKind(arg)
+				if err != nil {
+					log.Fatalf("Error: %v", err)
+				}
+				kinds = append(kinds, kind)
+			}
+		}
+		page := pageFromFlags(cmd, true)
+
+		at := clock()
+		var infos []snapshot.Info
+		interrupted := false
+		for _, kind := range kinds {
+			var snap snapshot.Snapshot
+			switch kind {
+			case snapshot.KindVaults:
+				vaults, err := client.FetchAllVault(cmd.Context())
+				if errors.Is(err, hlstats.ErrVaultsUnavailable) && !explicit {
+					log.Printf("Skipping %s: %v", kind, err)
+					continue
+				}
+				if err != nil {
+					fatal(err, "Error fetching vaults")
+				}
+				snap = snapshot.NewVaults(network, at, vaults)
+
+			case snapshot.KindVaultVolumes:
+				hlpOnly, _ := cmd.Flags().GetBool("hlp")
+				workers, _ := cmd.Flags().GetInt("workers")
+
+				volumes, err := client.FetchAllVaultVolumesConcurrent(cmd.Context(), hlpOnly, page, workers, vaultFilters(cmd)...)
+				finishProgress()
+				var failures hlstats.VaultFetchErrors
+				failures, interrupted = handleVaultFetchError(cmd, err, len(volumes), "Error fetching vault volumes")
+				snap = snapshot.NewVaultVolumes(network, at, volumes, len(failures) > 0 || interrupted)
+
+			case snapshot.KindLargestUsers:
+				users, err := client.FetchLargestUsers(cmd.Context())
+				if errors.Is(err, hlstats.ErrStatsUnavailable) && !explicit {
+					log.Printf("Skipping %s: %v", kind, err)
+					continue
+				}
+				if err != nil {
+					fatal(err, "Error fetching largest users")
+				}
+				snap = snapshot.NewLargestUsers(network, at, users)
+
+			case snapshot.KindTradeCounts:
+				counts, err := client.FetchLargestTradeCounts(cmd.Context())
+				if errors.Is(err, hlstats.ErrStatsUnavailable) && !explicit {
+					log.Printf("Skipping %s: %v", kind, err)
+					continue
+				}
+				if err != nil {
+					fatal(err, "Error fetching largest trade counts")
+				}
+				snap = snapshot.NewTradeCounts(network, at, counts)
+			}
+
+			if err := store.Save(snap); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			infos = append(infos, snap.Info())
+			if interrupted {
+				break
+			}
+		}
+
+		render(view.SnapshotInfos(infos))
+		if interrupted {
+			os.Exit(exitInterrupted)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().Bool("hlp", false, "Record only the volumes of HLP vaults")
+	snapshotCmd.Flags().IntP("workers", "w", 5, "Number of concurrent workers for fetching vault volumes")
+	addPageFlags(snapshotCmd, 0, "vaults")
+	addVaultFilterFlags(snapshotCmd, 10)
+	snapshotCmd.Flags().Bool("fail-on-partial", false, "Exit with an error if any vault fails to fetch")
+	snapshotCmd.Flags().Int("max-failures", -1, "Exit with an error if more than this many vaults fail to fetch (-1 for no limit)")
+}
+
+// openStore opens the snapshot store selected by --store-dir.
+func openStore() *snapshot.Store {
+	store, err := snapshot.Open(cfg.StoreDir)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	return store
+}
+
+// openExistingStore opens the snapshot store selected by --store-dir if its directory exists, and
+// returns nil otherwise, so that commands only reading snapshots never create the store.
+func openExistingStore() *snapshot.Store {
+	if _, err := os.Stat(cfg.StoreDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return openStore()
+}
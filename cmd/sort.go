@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// sortKeysHelp describes the syntax of the --sort-by flags.
+const sortKeysHelp = "comma-separated keys, each optionally suffixed with :asc or :desc"
+
+// sortKeys parses the --sort-by flag of cmd and validates it against fields. If hlpFirst is set and
+// the expression does not sort by hlp, HLP vaults are kept first.
+func sortKeys[T any](cmd *cobra.Command, fields hlstats.SortFields[T], hlpFirst bool) hlstats.SortKeys {
+	expr, _ := cmd.Flags().GetString("sort-by")
+	keys, err := hlstats.ParseSortKeys(expr)
+	if err != nil {
+		log.Fatalf("Error: invalid --sort-by: %v", err)
+	}
+	if err := fields.Validate(keys); err != nil {
+		log.Fatalf("Error: invalid --sort-by: %v", err)
+	}
+
+	if hlpFirst && !keys.Has("hlp") {
+		keys = append(hlstats.SortKeys{{Field: "hlp", Order: hlstats.SortDesc}}, keys...)
+	}
+
+	return keys
+}
@@ -0,0 +1,362 @@
+// Command fakeapi serves synthetic Hyperliquid stats and info responses, from which the fixtures in
+// cmd/testdata/recordings are recorded. The data is made up: it only has the shape of the real API,
+// with values varied enough for the command tests to catch decoding, ordering and aggregation
+// errors. See cmd/testdata/recordings/README.md.
+//
+//	go run ./cmd/testdata/fakeapi -addr 127.0.0.1:18086
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const hlpParent = "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303"
+
+// end is the time of the last portfolio sample, the current time of the command tests.
+var end = time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+
+// vault describes a synthetic vault.
+type vault struct {
+	address, name, leader, description string
+	tvl, apr                           float64
+	closed                             bool
+	created                            int64
+	relationship                       map[string]any
+	// dailyVolume is the volume of a day and perpShare the share of it traded on perps.
+	dailyVolume, perpShare float64
+	// returns is the pattern of returns between portfolio samples; periods scale and repeat it.
+	returns []float64
+	// deposit is added to the account value from the middle of each series on, without PnL.
+	deposit   float64
+	followers []follower
+}
+
+type follower struct {
+	user   string
+	equity float64
+	days   int
+}
+
+// The listing is deliberately not in TVL order, names differ from addresses and every vault has its
+// own volume, perp share and return profile.
+var vaults = []vault{
+	{
+		address: "0xa1", name: "HLP Strategy A", leader: hlpParent, description: "Market making on majors",
+		tvl: 1250000.75, apr: 0.12, created: 1700000000000,
+		relationship: map[string]any{"type": "child", "data": map[string]any{"parentAddress": hlpParent}},
+		dailyVolume:  1800000, perpShare: 1,
+		// Gains only
+		returns: []float64{0.002, 0.001, 0.003, 0, 0.0025, 0.001, 0.004, 0.0015, 0.002, 0.0005, 0.003},
+		followers: []follower{
+			{user: hlpParent, equity: 250000, days: 400},
+			{user: "0xf1", equity: 600000.5, days: 210},
+			{user: "0xf2", equity: 300000.25, days: 35},
+			{user: "0xf3", equity: 100000, days: 3},
+		},
+	},
+	{
+		address: hlpParent, name: "Hyperliquidity Provider (HLP)", leader: "0x677d831aef5328190852e24f13c46cac05f984e7",
+		description: "Community-owned liquidity", tvl: 1730000.75, apr: 0.1, created: 1680000000000,
+		relationship: map[string]any{"type": "parent", "data": map[string]any{"childAddresses": []string{"0xa1", "0xe5"}}},
+		dailyVolume:  2400000, perpShare: 0.9625,
+		returns:   []float64{0.0015, 0.0008, 0.0022, -0.0003, 0.0018, 0.0009, 0.0031, 0.0012, 0.0016, 0.0002, 0.0024},
+		followers: []follower{{user: "0xf4", equity: 1730000.75, days: 800}},
+	},
+	{
+		address: "0xe5", name: "HLP Liquidator", leader: hlpParent, description: "Liquidations",
+		tvl: 480000, apr: 0.04, created: 1700000000000,
+		relationship: map[string]any{"type": "child", "data": map[string]any{"parentAddress": hlpParent}},
+		dailyVolume:  600000, perpShare: 0.85,
+		returns:   []float64{0.001, -0.0005, 0.002, 0.0015, -0.001, 0.0008, 0.0012, -0.0002, 0.0009, 0.0011, -0.0004},
+		followers: []follower{{user: hlpParent, equity: 480000, days: 400}},
+	},
+	{
+		address: "0xb2", name: "Alpha", leader: "0x3", description: "Momentum on alts",
+		tvl: 212345.5, apr: 0.35, created: 1750000000000,
+		dailyVolume: 95000, perpShare: 0.6,
+		returns: []float64{0.02, -0.015, 0.03, -0.01, 0.025, -0.02, 0.01, 0.015, -0.005, 0.02, -0.012},
+		deposit: 50000,
+		followers: []follower{
+			{user: "0x3", equity: 42000, days: 120},
+			{user: "0xf5", equity: 170345.5, days: 60},
+		},
+	},
+	{
+		address: "0xc3", name: "Beta Basis", leader: "0x4", description: "Delta-neutral basis trade",
+		tvl: 61200, apr: 0.02, created: 1760000000000,
+		dailyVolume: 12000, perpShare: 0.1,
+		// Nearly flat: too little volatility for a meaningful Sharpe ratio
+		returns:   []float64{0.00005, 0.00006, 0.00005, 0.00006, 0.00005, 0.00006, 0.00005, 0.00006, 0.00005, 0.00006, 0.00005},
+		followers: []follower{{user: "0x4", equity: 61200, days: 20}},
+	},
+	{
+		address: "0xd4", name: "Retired", leader: "0x5", description: "Closed",
+		closed: true, created: 1690000000000,
+	},
+}
+
+// period is a portfolio period with its sampling.
+type period struct {
+	name    string
+	samples int
+	step    time.Duration
+	// scale multiplies the return pattern and days the daily volume.
+	scale, days float64
+}
+
+var periods = []period{
+	{name: "day", samples: 8, step: 3 * time.Hour, scale: 0.25, days: 1},
+	{name: "week", samples: 8, step: 24 * time.Hour, scale: 1, days: 6.5},
+	{name: "month", samples: 11, step: 3 * 24 * time.Hour, scale: 1.5, days: 27},
+	{name: "allTime", samples: 12, step: 30 * 24 * time.Hour, scale: 4, days: 400},
+}
+
+func find(address string) (vault, bool) {
+	for _, v := range vaults {
+		if strings.EqualFold(v.address, address) {
+			return v, true
+		}
+	}
+
+	return vault{}, false
+}
+
+// str formats a number the way the API does in string fields.
+func str(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// round rounds v to cents, as the API reports amounts.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// series returns the account value and PnL history of a period, ending at the vault's TVL.
+func (v vault) series(p period) (accountValues, pnls [][2]any) {
+	if len(v.returns) == 0 {
+		return [][2]any{}, [][2]any{}
+	}
+
+	// Walk backwards from the TVL, so that the last sample is the current account value
+	values := make([]float64, p.samples)
+	values[p.samples-1] = v.tvl
+	for i := p.samples - 1; i > 0; i-- {
+		r := v.returns[(i-1)%len(v.returns)] * p.scale
+		value := values[i]
+		if v.deposit != 0 && i == p.samples/2 {
+			value -= v.deposit
+		}
+		values[i-1] = value / (1 + r)
+	}
+
+	pnl := 0.0
+	for i := 0; i < p.samples; i++ {
+		at := end.Add(-time.Duration(p.samples-1-i) * p.step).UnixMilli()
+		if i > 0 {
+			pnl += values[i-1] * v.returns[(i-1)%len(v.returns)] * p.scale
+		}
+		accountValues = append(accountValues, [2]any{at, str(round(values[i]))})
+		pnls = append(pnls, [2]any{at, str(round(pnl))})
+	}
+
+	return accountValues, pnls
+}
+
+func (v vault) summary() map[string]any {
+	summary := map[string]any{
+		"name":             v.name,
+		"vaultAddress":     v.address,
+		"leader":           v.leader,
+		"tvl":              str(v.tvl),
+		"isClosed":         v.closed,
+		"createTimeMillis": v.created,
+	}
+	if v.relationship != nil {
+		summary["relationship"] = v.relationship
+	}
+
+	return summary
+}
+
+func (v vault) details() map[string]any {
+	var portfolio [][2]any
+	for _, market := range []string{"", "perp"} {
+		for _, p := range periods {
+			name := p.name
+			volume := v.dailyVolume * p.days
+			if market == "perp" {
+				name = "perp" + strings.ToUpper(name[:1]) + name[1:]
+				volume *= v.perpShare
+			}
+			accountValues, pnls := v.series(p)
+			portfolio = append(portfolio, [2]any{name, map[string]any{
+				"accountValueHistory": accountValues,
+				"pnlHistory":          pnls,
+				"vlm":                 str(round(volume)),
+			}})
+		}
+	}
+
+	followers := []map[string]any{}
+	for _, f := range v.followers {
+		followers = append(followers, map[string]any{
+			"user":           f.user,
+			"vaultEquity":    str(f.equity),
+			"pnl":            str(round(f.equity * 0.01)),
+			"allTimePnl":     str(round(f.equity * 0.04)),
+			"daysFollowing":  f.days,
+			"vaultEntryTime": end.Add(-time.Duration(f.days) * 24 * time.Hour).UnixMilli(),
+			"lockupUntil":    0,
+		})
+	}
+
+	relationship := v.relationship
+	if relationship == nil {
+		relationship = map[string]any{"type": "normal"}
+	}
+
+	return map[string]any{
+		"name":                  v.name,
+		"vaultAddress":          v.address,
+		"leader":                v.leader,
+		"description":           v.description,
+		"portfolio":             portfolio,
+		"apr":                   v.apr,
+		"followers":             followers,
+		"leaderFraction":        0.05 + v.apr/10,
+		"leaderCommission":      0.1,
+		"maxDistributable":      round(v.tvl * 0.9),
+		"maxWithdrawable":       round(v.tvl * 0.4),
+		"isClosed":              v.closed,
+		"relationship":          relationship,
+		"allowDeposits":         !v.closed && v.address != hlpParent,
+		"alwaysCloseOnWithdraw": v.address == "0xb2",
+	}
+}
+
+// days returns the days from the from_date to the to_date query parameters, both inclusive.
+func days(r *http.Request) ([]time.Time, error) {
+	from, err := time.Parse(time.DateOnly, r.URL.Query().Get("from_date"))
+	if err != nil {
+		return nil, err
+	}
+	to, err := time.Parse(time.DateOnly, r.URL.Query().Get("to_date"))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []time.Time
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		result = append(result, day)
+	}
+
+	return result, nil
+}
+
+// wave returns a deterministic value between 0 and 1 that varies irregularly with n.
+func wave(n int) float64 {
+	return float64((n*7919)%13) / 12
+}
+
+func send(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Print(err)
+	}
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:18086", "address to listen on")
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /vaults", func(w http.ResponseWriter, r *http.Request) {
+		var listing []map[string]any
+		for _, v := range vaults {
+			item := map[string]any{"summary": v.summary()}
+			if v.apr != 0 {
+				item["apr"] = v.apr
+			}
+			if v.address == "0xa1" {
+				item["pnls"] = [][2]any{{"day", []any{"0.0", "1.5"}}, {"week", []any{"0.0", "2.5", 3}}, {"month", []any{}}, {"allTime", []any{"0.0", "10.0"}}}
+			}
+			listing = append(listing, item)
+		}
+		send(w, listing)
+	})
+	mux.HandleFunc("GET /largest_users_by_usd_volume", func(w http.ResponseWriter, r *http.Request) {
+		send(w, map[string]any{"table_data": []map[string]any{
+			{"name": "0xu2", "value": 48250000.5},
+			{"name": "0xu1", "value": 31000000.0},
+			{"name": "0xu4", "value": 9875000.25},
+			{"name": "0xu3", "value": 512000.0},
+		}})
+	})
+	mux.HandleFunc("GET /largest_users_by_trade_count", func(w http.ResponseWriter, r *http.Request) {
+		send(w, map[string]any{"chart_data": []map[string]any{
+			{"name": "0xu3", "value": 18250},
+			{"name": "0xu1", "value": 9120},
+			{"name": "0xu5", "value": 433},
+		}})
+	})
+	mux.HandleFunc("GET /daily_usd_volume", func(w http.ResponseWriter, r *http.Request) {
+		days, err := days(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var data []map[string]any
+		for _, day := range days {
+			data = append(data, map[string]any{
+				"time":             day.Format("2006-01-02T15:04:05"),
+				"daily_usd_volume": round(1.6e9 + wave(day.YearDay())*1.4e9),
+			})
+		}
+		send(w, map[string]any{"table_name": "daily_usd_volume", "chart_data": data})
+	})
+	mux.HandleFunc("GET /daily_usd_volume_by_user", func(w http.ResponseWriter, r *http.Request) {
+		days, err := days(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var data []map[string]any
+		for _, day := range days {
+			for i, user := range []string{"0xu1", "0xu2", "0xu3"} {
+				data = append(data, map[string]any{
+					"time":             day.Format("2006-01-02T15:04:05"),
+					"user":             user,
+					"daily_usd_volume": round(float64(3-i)*2.5e6 + wave(day.YearDay()+i)*1.2e6),
+				})
+			}
+		}
+		send(w, map[string]any{"chart_data": data})
+	})
+	mux.HandleFunc("POST /info", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Type    string `json:"type"`
+			Address string `json:"vaultAddress"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Type != "vaultDetails" {
+			http.Error(w, "unsupported request", http.StatusBadRequest)
+			return
+		}
+		v, ok := find(payload.Address)
+		if !ok {
+			http.Error(w, "unknown vault", http.StatusBadRequest)
+			return
+		}
+		send(w, v.details())
+	})
+
+	fmt.Printf("serving synthetic API responses on http://%s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/spf13/cobra"
+)
+
+// vaultDetailsCmd represents the vault-details command
+var vaultDetailsCmd = &cobra.Command{
+	Use:     "vault-details",
+	Aliases: []string{"vdetails", "vd"},
+	Short:   "Fetch full details of a vault",
+	Long: `Fetch and display the full vaultDetails response for a single vault.
+
+Details include the name, leader, description, status, parent/child relationship,
+APR, follower count, leader fraction and commission, max distributable and withdrawable
+amounts, and the volume, account value and PnL of every portfolio period.
+JSON and NDJSON output carry the complete model including followers and time series;
+CSV output lists the account value and PnL time series, one row per sample.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		query, _ := cmd.Flags().GetString("address")
+		address := resolveVault(cmd.Context(), client, query).Data.Address
+
+		details, err := client.FetchVaultDetails(cmd.Context(), address)
+		if err != nil {
+			fatal(err, fmt.Sprintf("Error fetching vault details for address %s", address))
+		}
+
+		render(view.VaultDetails(details))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vaultDetailsCmd)
+	vaultDetailsCmd.Flags().String("address", "", "Vault to fetch details for: "+vaultQueryHelp)
+	_ = vaultDetailsCmd.MarkFlagRequired("address")
+}
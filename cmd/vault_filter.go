@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// addVaultFilterFlags registers the vault listing filter flags on cmd, with minTVL as the default
+// of --min-tvl.
+func addVaultFilterFlags(cmd *cobra.Command, minTVL float64) {
+	cmd.Flags().Float64("min-tvl", minTVL, "Minimum TVL threshold for vault filtering")
+	cmd.Flags().Float64("max-tvl", 0, "Maximum TVL threshold for vault filtering (no limit if unset)")
+	cmd.Flags().Bool("include-closed", false, "Include closed vaults")
+	cmd.Flags().StringSlice("leader", nil, "Only vaults led by one of these addresses")
+	cmd.Flags().String("name-regex", "", "Only vaults whose name matches this regular expression (use (?i) to ignore case)")
+	cmd.Flags().String("created-after", "", "Only vaults created after a date (YYYY-MM-DD) or within a range (e.g. 30D, 6M, 1Y)")
+	cmd.Flags().Float64("min-apr", 0, "Minimum APR as a fraction, e.g. 0.1 for 10% (no limit if unset)")
+	cmd.Flags().Float64("max-apr", 0, "Maximum APR as a fraction (no limit if unset)")
+}
+
+// vaultFilters builds the vault predicates selected by the flags registered with addVaultFilterFlags.
+func vaultFilters(cmd *cobra.Command) []hlstats.VaultPredicate {
+	flags := cmd.Flags()
+
+	minTVL, _ := flags.GetFloat64("min-tvl")
+	filters := []hlstats.VaultPredicate{hlstats.MinTVL(minTVL)}
+
+	if includeClosed, _ := flags.GetBool("include-closed"); !includeClosed {
+		filters = append(filters, hlstats.OpenOnly())
+	}
+	if flags.Changed("max-tvl") {
+		maxTVL, _ := flags.GetFloat64("max-tvl")
+		filters = append(filters, hlstats.MaxTVL(maxTVL))
+	}
+	if leaders, _ := flags.GetStringSlice("leader"); len(leaders) > 0 {
+		filters = append(filters, hlstats.LeaderIn(leaders...))
+	}
+	if pattern, _ := flags.GetString("name-regex"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Fatalf("Error: invalid --name-regex: %v", err)
+		}
+		filters = append(filters, hlstats.NameMatches(re))
+	}
+	if createdAfter, _ := flags.GetString("created-after"); createdAfter != "" {
+		filters = append(filters, hlstats.CreatedAfter(parseSince("created-after", createdAfter)))
+	}
+	if flags.Changed("min-apr") {
+		minAPR, _ := flags.GetFloat64("min-apr")
+		filters = append(filters, hlstats.MinAPR(minAPR))
+	}
+	if flags.Changed("max-apr") {
+		maxAPR, _ := flags.GetFloat64("max-apr")
+		filters = append(filters, hlstats.MaxAPR(maxAPR))
+	}
+
+	return filters
+}
+
+// parseSince parses the value of a flag that is a YYYY-MM-DD date or a range such as 30D relative
+// to now.
+func parseSince(flag, value string) time.Time {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t
+	}
+
+	from, _, err := parseRangeFlag(value, clock())
+	if err != nil {
+		log.Fatalf("Error: invalid --%s: expected YYYY-MM-DD or a range: %v", flag, err)
+	}
+
+	return *from
+}
+
+// parseUntil parses the value of a flag that is a YYYY-MM-DD date, inclusive of the whole day, or a
+// range such as 7D ending that long before now.
+func parseUntil(flag, value string) time.Time {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	return parseSince(flag, value)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// vaultFollowersCmd represents the vault-followers command
+var vaultFollowersCmd = &cobra.Command{
+	Use:     "vault-followers",
+	Aliases: []string{"followers", "vfol"},
+	Short:   "List the depositors of a vault and their concentration",
+	Long: `Fetch the depositors of a vault, sorted by equity, together with their concentration:
+the share of equity held by the --top-n largest depositors, the Herfindahl-Hirschman
+index (HHI) of the equity shares and the leader's own stake.
+
+Concentration is always computed over all depositors; --limit, --offset, --top and
+--bottom only select the depositors listed.
+JSON output includes the concentration; CSV and NDJSON contain one record per depositor.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		topN, _ := cmd.Flags().GetInt("top-n")
+		if topN < 1 {
+			log.Fatal("Error: --top-n must be at least 1")
+		}
+
+		client := newClient()
+
+		query, _ := cmd.Flags().GetString("address")
+		address := resolveVault(cmd.Context(), client, query).Data.Address
+		page := pageFromFlags(cmd, false)
+
+		details, err := client.FetchVaultDetails(cmd.Context(), address)
+		if err != nil {
+			fatal(err, fmt.Sprintf("Error fetching vault followers for address %s", address))
+		}
+
+		concentration := details.FollowerConcentration(topN)
+		followers := hlstats.Paginate(details.Followers.SortByEquity(), page)
+		rows := view.NewVaultFollowers(followers, concentration.TotalEquity)
+
+		switch outputFormat() {
+		case common.FormatTable, common.FormatJSON:
+			render(view.VaultFollowersReport{
+				Address:       details.Address,
+				Name:          details.Name,
+				Leader:        details.Leader,
+				Concentration: concentration,
+				Followers:     rows,
+			})
+		default:
+			render(rows)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vaultFollowersCmd)
+	vaultFollowersCmd.Flags().String("address", "", "Vault to list followers for: "+vaultQueryHelp)
+	vaultFollowersCmd.Flags().Int("top-n", 10, "Number of largest depositors for the top-N equity share")
+	addPageFlags(vaultFollowersCmd, 0, "depositors")
+	_ = vaultFollowersCmd.MarkFlagRequired("address")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// vaultPerformanceCmd represents the vault-performance command
+var vaultPerformanceCmd = &cobra.Command{
+	Use:     "vault-performance",
+	Aliases: []string{"vault-perf", "vperf"},
+	Short:   "Rank vaults by return, drawdown, volatility and risk-adjusted return",
+	Long: `Fetch the account value and PnL history of all open vaults and compute, per period
+(day, week, month, all-time):
+
+  return       time-weighted return, excluding deposits and withdrawals
+  drawdown     maximum drawdown of the time-weighted return
+  volatility   annualised volatility of the returns between samples
+  sharpe       annualised Sharpe ratio (zero risk-free rate)
+  sortino      annualised Sortino ratio (zero risk-free rate)
+  pnl-volume   PnL earned per unit of volume traded
+
+Sharpe and Sortino are undefined (shown as -) with fewer than 5 returns or an annualised
+volatility below 1%, and Sortino is unbounded (∞) if no return is a loss.
+
+Vaults are ranked best first by --sort-by for the --period shown in the table: ascending
+for drawdown and volatility, descending otherwise. Several keys can be combined and given
+a direction, e.g. --sort-by sharpe,drawdown:asc; tvl, name, address and hlp are also accepted.
+Use --hlp, --limit, --offset, --top, --bottom and --workers as for vault-volume.
+Machine-readable formats carry every period; CSV has one row per vault and period.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		progress, finishProgress := newProgress()
+		client := newClient(hlstats.WithProgressReporter(progress))
+
+		page := pageFromFlags(cmd, false)
+		hlpOnly, _ := cmd.Flags().GetBool("hlp")
+		workers, _ := cmd.Flags().GetInt("workers")
+		period, _ := cmd.Flags().GetString("period")
+
+		// Validate the ranking before spending requests on it
+		fields, err := hlstats.VaultPerformanceSortFields(period)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		keys := sortKeys(cmd, fields, false)
+		period = strings.ToLower(period)
+
+		performances, err := client.FetchAllVaultPerformancesConcurrent(cmd.Context(), hlpOnly, page, workers)
+		finishProgress()
+		failures, interrupted := handleVaultFetchError(cmd, err, len(performances), "Error fetching vault performance")
+		if interrupted {
+			defer os.Exit(exitInterrupted)
+		}
+
+		performances, _ = performances.SortBy(period, keys)
+
+		switch outputFormat() {
+		case common.FormatTable, common.FormatJSON:
+			render(view.VaultPerformanceReport{
+				Period:   period,
+				Vaults:   view.VaultPerformances(performances),
+				Failures: view.VaultFetchErrors(failures),
+			})
+		default:
+			render(view.VaultPerformances(performances))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vaultPerformanceCmd)
+	vaultPerformanceCmd.Flags().Bool("hlp", false, "Show only HLP vaults")
+	addPageFlags(vaultPerformanceCmd, 0, "vaults")
+	vaultPerformanceCmd.Flags().IntP("workers", "w", 5, "Number of concurrent workers for fetching vault details")
+	vaultPerformanceCmd.Flags().String("period", "month", "Period to rank and display: day, week, month, all-time")
+	vaultPerformanceCmd.Flags().String("sort-by", hlstats.MetricSharpe, "Rank by "+sortKeysHelp+": return, drawdown, volatility, sharpe, sortino, pnl-volume, tvl, name, address, hlp")
+	vaultPerformanceCmd.Flags().Bool("fail-on-partial", false, "Exit with an error if any vault fails to fetch")
+	vaultPerformanceCmd.Flags().Int("max-failures", -1, "Exit with an error if more than this many vaults fail to fetch (-1 for no limit)")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// vaultQueryHelp describes the vault queries accepted by --address flags and vault-search.
+const vaultQueryHelp = "vault address, unique address prefix, name substring or leader:<address>"
+
+// vaultSearchCmd represents the vault-search command
+var vaultSearchCmd = &cobra.Command{
+	Use:     "vault-search <query>",
+	Aliases: []string{"vsearch", "find-vault"},
+	Short:   "Find vaults by address, address prefix, name or leader",
+	Long: `Find vaults in the vault listing. The query is one of:
+
+  0x1234...abcd    a full vault address (case-insensitive)
+  0x12             an address prefix
+  leader:0xabc...  vaults led by an address (full address or prefix)
+  growth           a case-insensitive substring of the vault name
+
+Every command that takes a vault --address accepts the same queries, as long as
+they identify a single vault; otherwise the matching vaults are listed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		vaults, err := client.SearchVaults(cmd.Context(), args[0])
+		if err != nil {
+			fatal(err, "Error searching vaults")
+		}
+
+		openOnly, _ := cmd.Flags().GetBool("open")
+		if openOnly {
+			vaults = vaults.FilterOpenVaults()
+		}
+		vaults = hlstats.Paginate(vaults.SortWithHLPPriority(false), pageFromFlags(cmd, false))
+
+		if len(vaults) == 0 {
+			log.Printf("No vault matches %q", args[0])
+		}
+		render(view.VaultSearchResults(vaults))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vaultSearchCmd)
+	vaultSearchCmd.Flags().Bool("open", false, "Show only open vaults")
+	addPageFlags(vaultSearchCmd, 0, "vaults")
+}
+
+// resolveVault resolves a vault query given on the command line to a single vault. If the query
+// is ambiguous, the matching vaults are listed on stderr and the process exits.
+func resolveVault(ctx context.Context, client *hlstats.Client, query string) hlstats.Vault {
+	vault, err := client.ResolveVault(ctx, query)
+	return checkResolvedVault(query, vault, err)
+}
+
+// checkResolvedVault returns a resolved vault, or lists the matching vaults on stderr and exits if
+// the query was ambiguous.
+func checkResolvedVault(query string, vault hlstats.Vault, err error) hlstats.Vault {
+	var ambiguous *hlstats.AmbiguousVaultError
+	if errors.As(err, &ambiguous) {
+		log.Printf("Error: %v; use the full address or a longer prefix:", err)
+		fmt.Fprintln(os.Stderr, view.VaultSearchResults(ambiguous.Matches.SortWithHLPPriority(false)).FormatTable())
+		os.Exit(exitError)
+	}
+	if err != nil {
+		fatal(err, fmt.Sprintf("Error resolving vault %s", query))
+	}
+
+	return vault
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/spf13/cobra"
+)
+
+// vaultTreeCmd represents the vault-tree command
+var vaultTreeCmd = &cobra.Command{
+	Use:     "vault-tree",
+	Aliases: []string{"vtree"},
+	Short:   "Show parent vaults with their child vaults and rolled-up figures",
+	Long: `Resolve the parent/child relationships of vaults from vaultDetails and display each
+parent vault with its children indented below it.
+
+Parent rows show rolled-up figures: a parent allocates its capital to its children
+and its figures duplicate theirs, so its TVL, volume and PnL are the sums over its
+children. Child and standalone rows show the vault's own figures.
+
+By default the HLP parent vault of the selected network is shown. Use --address
+(repeatable) to show other vaults; a child vault address shows its whole family.
+JSON output is nested; CSV has one row per vault and NDJSON one line per tree.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		progress, finishProgress := newProgress()
+		client := newClient(hlstats.WithProgressReporter(progress))
+
+		queries, _ := cmd.Flags().GetStringSlice("address")
+		workers, _ := cmd.Flags().GetInt("workers")
+
+		var addresses []string
+		for _, query := range queries {
+			addresses = append(addresses, resolveVault(cmd.Context(), client, query).Data.Address)
+		}
+		if len(addresses) == 0 {
+			if client.Network().HLPParent == "" {
+				log.Fatalf("Error: network %s has no HLP parent vault; use --address", client.Network().Name)
+			}
+			addresses = []string{client.Network().HLPParent}
+		}
+
+		tree, err := client.FetchVaultTree(cmd.Context(), addresses, workers)
+		finishProgress()
+		failures, interrupted := handleVaultFetchError(cmd, err, len(tree), "Error fetching vault tree")
+		if interrupted {
+			defer os.Exit(exitInterrupted)
+		}
+
+		switch outputFormat() {
+		case common.FormatTable, common.FormatJSON:
+			render(view.VaultTreeReport{Vaults: view.VaultTree(tree), Failures: view.VaultFetchErrors(failures)})
+		default:
+			render(view.VaultTree(tree))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vaultTreeCmd)
+	vaultTreeCmd.Flags().StringSlice("address", nil, "Vaults to show, each a "+vaultQueryHelp+" (default: the network's HLP parent vault)")
+	vaultTreeCmd.Flags().IntP("workers", "w", 5, "Number of concurrent workers for fetching vault details")
+	vaultTreeCmd.Flags().Bool("fail-on-partial", false, "Exit with an error if any vault fails to fetch")
+	vaultTreeCmd.Flags().Int("max-failures", -1, "Exit with an error if more than this many vaults fail to fetch (-1 for no limit)")
+}
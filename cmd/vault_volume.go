@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"time"
+	"os"
 
-	"github.com/LampardNguyen234/hyperliquid-stats/internal/api"
+	"github.com/LampardNguyen234/hyperliquid-stats/internal/view"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -19,41 +23,71 @@ var vaultVolumeCmd = &cobra.Command{
 This command retrieves volume data for all vaults or a specific vault by address.
 Volume data includes day, week, month, and all-time statistics for both regular and perpetual trading.
 Additionally displays the last 7 days of daily volume data for context.
-Results are sorted by HLP priority first, then by the specified field descending.
+Results are sorted by HLP priority first, then by the --sort-by keys.
 Use --hlp flag to show only HLP vault volumes.
-Use --count flag to limit the number of vaults displayed.
+Use --limit, --offset, --top or --bottom to select the vaults to fetch after filtering,
+in listing order (HLP first, then by TVL); --sort-by then orders the selected vaults.
 Use --workers flag to control concurrent fetching (default: 5 workers).
-Use --sort-by flag to sort by tvl, day, week, month, or all-time (default: tvl).
-Use --summary flag to display aggregated totals and top 10 vaults by TVL.`,
+Use --sort-by flag to sort by tvl, day, week, month, all-time, name, address or hlp
+(default: tvl), e.g. --sort-by month:desc,name:asc. Numbers sort descending and text
+ascending unless :asc or :desc is given; HLP vaults stay first unless hlp is a key.
+Use --market flag to show and sort by total (all), perp or spot volumes (default: all);
+spot volume is derived as total minus perp volume.
+Use --summary flag to display aggregated totals and top 10 vaults by TVL.
+Use the listing filters (--min-tvl, --max-tvl, --include-closed, --leader, --name-regex,
+--created-after, --min-apr, --max-apr) to select the vaults to fetch; all filters must match.
+Vaults that fail to fetch are listed after the table (or under "failures" in JSON output);
+use --fail-on-partial or --max-failures to turn partial results into an error.
+Machine-readable formats (--format json|csv|ndjson) always output per-vault records.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := api.NewClient(cfg.BaseURL, cfg.InfoURL)
+		progress, finishProgress := newProgress()
+		client := newClient(hlstats.WithProgressReporter(progress))
 
 		address, _ := cmd.Flags().GetString("address")
 
+		marketFlag, _ := cmd.Flags().GetString("market")
+		market, err := hlstats.ParseMarket(marketFlag)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		// Validate the sorting before spending requests on it
+		keys := sortKeys(cmd, hlstats.VaultVolumeSortFields(market), true)
+
 		if address != "" {
+			vault := resolveVault(cmd.Context(), client, address)
+			address = vault.Data.Address
+
 			// Fetch specific vault volume
-			volume, err := client.FetchVaultVolume(address)
+			volume, err := client.FetchVaultVolume(cmd.Context(), address)
 			if err != nil {
-				log.Fatalf("Error fetching vault volume for address %s: %v", address, err)
+				fatal(err, fmt.Sprintf("Error fetching vault volume for address %s", address))
 			}
 
-			// Get vault name from vault list (optional, fallback to address)
-			vaults, _ := client.FetchAllVault()
-			vaultName := address
-			for _, vault := range vaults {
-				if vault.Data.Address == address {
-					vaultName = vault.Data.Name
-					break
-				}
+			// Unlisted vaults have no name; fall back to the address
+			vaultName := vault.Data.Name
+			if vaultName == "" {
+				vaultName = address
+			}
+
+			if !isTableFormat() {
+				render(view.VaultVolumesInfo{{
+					Address: address,
+					Name:    vaultName,
+					Volume:  volume,
+					TVL:     vault.Data.TVL,
+					IsHLP:   vault.IsHLP(),
+				}})
+				return
 			}
 
-			fmt.Println(volume.FormatSingle(vaultName, address))
+			fmt.Println(view.VaultVolume(volume).FormatSingle(vaultName, address))
 
 			// Fetch and display last 7 days daily volume
-			fetchAndDisplayDailyVolume(client)
+			fetchAndDisplayDailyVolume(cmd.Context(), client)
 		} else {
-			// Get count for display limiting
-			count, _ := cmd.Flags().GetInt("count")
+			// Select the vaults to fetch
+			page := pageFromFlags(cmd, false)
 
 			// Apply HLP filtering if requested
 			hlpOnly, _ := cmd.Flags().GetBool("hlp")
@@ -62,23 +96,34 @@ Use --summary flag to display aggregated totals and top 10 vaults by TVL.`,
 			workers, _ := cmd.Flags().GetInt("workers")
 
 			// Fetch all vault volumes concurrently
-			volumes, err := client.FetchAllVaultVolumesConcurrent(hlpOnly, count, workers)
-			if err != nil {
-				log.Fatalf("Error fetching all vault volumes: %v", err)
+			volumes, err := client.FetchAllVaultVolumesConcurrent(cmd.Context(), hlpOnly, page, workers, vaultFilters(cmd)...)
+			finishProgress()
+			failures, interrupted := handleVaultFetchError(cmd, err, len(volumes), "Error fetching all vault volumes")
+			if interrupted {
+				// Show what was collected before exiting with an error
+				defer os.Exit(exitInterrupted)
 			}
 
 			// Check if summary mode is requested
 			summaryMode, _ := cmd.Flags().GetBool("summary")
-			if summaryMode {
-				fmt.Println(volumes.FormatSummary())
+			if summaryMode && isTableFormat() {
+				fmt.Println(view.VaultVolumesInfo(volumes).FormatSummary(market))
+				if len(failures) > 0 {
+					fmt.Println(view.VaultFetchErrors(failures).FormatString())
+				}
 				// Fetch and display last 7 days daily volume
-				fetchAndDisplayDailyVolume(client)
+				fetchAndDisplayDailyVolume(cmd.Context(), client)
 			} else {
-				// Apply sorting by specified field
-				sortBy, _ := cmd.Flags().GetString("sort-by")
-				volumes = volumes.SortByField(sortBy)
-
-				fmt.Println(volumes.FormatString())
+				// Apply sorting by the specified keys
+				volumes, _ = volumes.SortBy(keys, market)
+
+				switch outputFormat() {
+				case common.FormatTable, common.FormatJSON:
+					render(view.VaultVolumesReport{Market: market, Vaults: view.VaultVolumesInfo(volumes), Failures: view.VaultFetchErrors(failures)})
+				default:
+					// Row-oriented formats carry only vault records; failures are reported on stderr
+					render(view.NewMarketVaultVolumes(volumes, market))
+				}
 			}
 		}
 	},
@@ -86,23 +131,66 @@ Use --summary flag to display aggregated totals and top 10 vaults by TVL.`,
 
 func init() {
 	rootCmd.AddCommand(vaultVolumeCmd)
-	vaultVolumeCmd.Flags().String("address", "", "Specific vault address to fetch volume for")
+	vaultVolumeCmd.Flags().String("address", "", "Specific vault to fetch volume for: "+vaultQueryHelp)
 	vaultVolumeCmd.Flags().Bool("hlp", false, "Show only HLP vaults")
-	vaultVolumeCmd.Flags().IntP("count", "c", 0, "Number of vaults to display (0 for all)")
+	addPageFlags(vaultVolumeCmd, 0, "vaults")
 	vaultVolumeCmd.Flags().IntP("workers", "w", 5, "Number of concurrent workers for fetching vault volumes")
-	vaultVolumeCmd.Flags().String("sort-by", "tvl", "Sort results by: tvl, day, week, month, all-time (HLP vaults always first)")
+	vaultVolumeCmd.Flags().String("sort-by", "tvl", "Sort results by "+sortKeysHelp+": tvl, day, week, month, all-time, name, address, hlp (HLP vaults first unless hlp is a key)")
+	vaultVolumeCmd.Flags().String("market", string(hlstats.MarketAll), "Volumes to show and sort by: all, perp, spot (spot = total - perp)")
 	vaultVolumeCmd.Flags().Bool("summary", false, "Display summary of vault volumes (totals by HLP/non-HLP and top 10 TVL)")
+	addVaultFilterFlags(vaultVolumeCmd, 10)
+	vaultVolumeCmd.Flags().Bool("fail-on-partial", false, "Exit with an error if any vault fails to fetch")
+	vaultVolumeCmd.Flags().Int("max-failures", -1, "Exit with an error if more than this many vaults fail to fetch (-1 for no limit)")
+}
+
+// checkFailurePolicy applies the --fail-on-partial and --max-failures flags to a partial fetch.
+func checkFailurePolicy(cmd *cobra.Command, partial *hlstats.PartialFetchError) error {
+	failOnPartial, _ := cmd.Flags().GetBool("fail-on-partial")
+	if failOnPartial && len(partial.Failures) > 0 {
+		return errors.Wrap(partial, "--fail-on-partial is set")
+	}
+
+	maxFailures, _ := cmd.Flags().GetInt("max-failures")
+	if maxFailures >= 0 && len(partial.Failures) > maxFailures {
+		return errors.Wrapf(partial, "more than %d failures (--max-failures)", maxFailures)
+	}
+
+	return nil
+}
+
+// handleVaultFetchError handles the error of a concurrent per-vault fetch that returned fetched
+// results. Partial failures are checked against the failure policy and returned; other errors are
+// fatal unless the fetch was interrupted with some results, in which case interrupted is true and
+// the caller should print the results before exiting with exitInterrupted.
+func handleVaultFetchError(cmd *cobra.Command, err error, fetched int, msg string) (failures hlstats.VaultFetchErrors, interrupted bool) {
+	failures = hlstats.VaultFetchErrors{}
+	if partial, ok := hlstats.AsPartialFetchError(err); ok {
+		if err := checkFailurePolicy(cmd, partial); err != nil {
+			log.Printf("Error: %v", err)
+			os.Exit(exitPartialFailure)
+		}
+		log.Printf("Warning: %v", partial)
+		return partial.Failures, false
+	} else if err != nil {
+		if cmd.Context().Err() == nil || fetched == 0 {
+			fatal(err, msg)
+		}
+		log.Printf("Warning: %v; showing partial results", err)
+		return failures, true
+	}
+
+	return failures, false
 }
 
 // fetchAndDisplayDailyVolume fetches and displays the last 7 days of daily volume data
-func fetchAndDisplayDailyVolume(client *api.Client) {
+func fetchAndDisplayDailyVolume(ctx context.Context, client *hlstats.Client) {
 	// Calculate date range for last 7 days
-	now := time.Now()
+	now := clock()
 	fromDate := now.AddDate(0, 0, -7) // 7 days ago
 	toDate := now
 
 	// Fetch daily volume data
-	dailyVolumes, err := client.FetchDailyVolume(&fromDate, &toDate)
+	dailyVolumes, err := client.FetchDailyVolume(ctx, &fromDate, &toDate)
 	if err != nil {
 		log.Printf("Warning: Failed to fetch daily volume data: %v", err)
 		return
@@ -118,5 +206,5 @@ func fetchAndDisplayDailyVolume(client *api.Client) {
 
 	// Display the daily volume data
 	fmt.Printf("\n=== LAST 7 DAYS DAILY VOLUME ===\n")
-	fmt.Println(dailyVolumes.FormatString(7))
+	fmt.Println(view.DailyVolumes(dailyVolumes).FormatString(7))
 }
@@ -1,23 +1,154 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 const (
-	DefaultBaseURL = "https://d2v1fiwobg9w6.cloudfront.net"
-	DefaultInfoURL = "https://api.hyperliquid.xyz/info"
+	DefaultNetwork = "mainnet"
+	DefaultFormat  = "table"
+
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultStoreDir is the snapshot store directory, relative to the home directory.
+	DefaultStoreDir = ".hype-stats/snapshots"
+	// DefaultCacheDir is the response cache directory, relative to the home directory.
+	DefaultCacheDir = ".hype-stats/cache"
 )
 
 type Config struct {
+	// Network is the selected network profile, with any URL overrides applied.
+	Network hlstats.Network
 	BaseURL string
 	InfoURL string
 	Format  string
+	Quiet   bool
+	Verbose bool
+
+	Timeout   time.Duration
+	UserAgent string
+
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	RetryStatuses    []int
+
+	RateLimits       []string
+	RateLimitWeights []string
+	RateLimitFile    string
+
+	// StoreDir is the directory of the snapshot store.
+	StoreDir string
+
+	// CacheDir is the directory of the response cache.
+	CacheDir  string
+	CacheTTLs []string
+	NoCache   bool
+	Refresh   bool
+	Offline   bool
+
+	// RecordDir receives a recording of every response; ReplayDir serves recordings instead of
+	// sending requests.
+	RecordDir string
+	ReplayDir string
 }
 
-func New() *Config {
-	return &Config{
-		BaseURL: viper.GetString("base_url"),
-		InfoURL: viper.GetString("info_url"),
+// networkProfile is a profile of the "networks" section of the config file.
+type networkProfile struct {
+	BaseURL   string `mapstructure:"base_url"`
+	InfoURL   string `mapstructure:"info_url"`
+	VaultsURL string `mapstructure:"vaults_url"`
+	HLPLeader string `mapstructure:"hlp_leader"`
+	HLPParent string `mapstructure:"hlp_parent"`
+}
+
+// New builds the configuration from viper. The network profile is looked up in the "networks"
+// section of the config file first, then in the built-in profiles; the base_url, info_url and
+// vaults_url keys override the profile URLs.
+func New() (*Config, error) {
+	var profiles map[string]networkProfile
+	if err := viper.UnmarshalKey("networks", &profiles); err != nil {
+		return nil, errors.Wrap(err, "invalid networks section in config")
+	}
+	custom := make(map[string]hlstats.Network, len(profiles))
+	for name, p := range profiles {
+		custom[name] = hlstats.Network{
+			Name:      name,
+			BaseURL:   p.BaseURL,
+			InfoURL:   p.InfoURL,
+			VaultsURL: p.VaultsURL,
+			HLPLeader: p.HLPLeader,
+			HLPParent: p.HLPParent,
+		}
+	}
+
+	network, err := hlstats.LookupNetwork(viper.GetString("network"), custom)
+	if err != nil {
+		return nil, err
 	}
+	if v := viper.GetString("base_url"); v != "" {
+		network.BaseURL = v
+	}
+	if v := viper.GetString("info_url"); v != "" {
+		network.InfoURL = v
+	}
+	if v := viper.GetString("vaults_url"); v != "" {
+		network.VaultsURL = v
+	}
+
+	storeDir := viper.GetString("store_dir")
+	if storeDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to locate the default snapshot store")
+		}
+		storeDir = filepath.Join(home, DefaultStoreDir)
+	}
+
+	cacheDir := viper.GetString("cache_dir")
+	if cacheDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to locate the default cache directory")
+		}
+		cacheDir = filepath.Join(home, DefaultCacheDir)
+	}
+
+	return &Config{
+		Network: network,
+		BaseURL: network.BaseURL,
+		InfoURL: network.InfoURL,
+		Format:  viper.GetString("format"),
+		Quiet:   viper.GetBool("quiet"),
+		Verbose: viper.GetBool("verbose"),
+
+		Timeout:   viper.GetDuration("timeout"),
+		UserAgent: viper.GetString("user_agent"),
+
+		RetryMaxAttempts: viper.GetInt("retry_max_attempts"),
+		RetryBaseDelay:   viper.GetDuration("retry_base_delay"),
+		RetryMaxDelay:    viper.GetDuration("retry_max_delay"),
+		RetryStatuses:    viper.GetIntSlice("retry_statuses"),
+
+		RateLimits:       viper.GetStringSlice("rate_limits"),
+		RateLimitWeights: viper.GetStringSlice("rate_limit_weights"),
+		RateLimitFile:    viper.GetString("rate_limit_file"),
+
+		StoreDir: storeDir,
+
+		CacheDir:  cacheDir,
+		CacheTTLs: viper.GetStringSlice("cache_ttls"),
+		NoCache:   viper.GetBool("no_cache"),
+		Refresh:   viper.GetBool("refresh"),
+		Offline:   viper.GetBool("offline"),
+
+		RecordDir: viper.GetString("record_dir"),
+		ReplayDir: viper.GetString("replay_dir"),
+	}, nil
 }
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// CacheStats renders the per-endpoint statistics of a response cache.
+type CacheStats []hlstats.CacheStats
+
+func (data CacheStats) FormatTable() string {
+	ret := common.NewTableFormatter()
+	ret = ret.WithHeader("Endpoint", "Entries", "Expired", "Size", "Oldest", "Newest")
+
+	var entries, expired int
+	var size int64
+	for _, stats := range data {
+		ret = ret.WithRow(
+			stats.Endpoint,
+			strconv.Itoa(stats.Entries),
+			strconv.Itoa(stats.Expired),
+			formatBytes(stats.Bytes),
+			stats.Oldest.Local().Format(snapshotTimeLayout),
+			stats.Newest.Local().Format(snapshotTimeLayout),
+		)
+		entries += stats.Entries
+		expired += stats.Expired
+		size += stats.Bytes
+	}
+	ret = ret.WithFooter("Total", strconv.Itoa(entries), strconv.Itoa(expired), formatBytes(size), "", "")
+
+	return ret.String()
+}
+
+func (data CacheStats) Header() []string {
+	return []string{"endpoint", "entries", "expired", "bytes", "oldest", "newest"}
+}
+
+func (data CacheStats) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, stats := range data {
+		records = append(records, []string{
+			stats.Endpoint,
+			strconv.Itoa(stats.Entries),
+			strconv.Itoa(stats.Expired),
+			strconv.FormatInt(stats.Bytes, 10),
+			formatTime(stats.Oldest),
+			formatTime(stats.Newest),
+		})
+	}
+
+	return records
+}
+
+// formatBytes formats a size in bytes with a binary unit.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,54 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// DailyVolumes renders hlstats.DailyVolumes.
+type DailyVolumes hlstats.DailyVolumes
+
+// FormatString formats the daily volume data as a table string
+func (data DailyVolumes) FormatString(count int) string {
+	ret := common.NewTableFormatter().WithHeader("Daily Volume")
+	ret = ret.WithHeader("Date", "Volume ($B)")
+
+	if count > len(data) {
+		count = len(data)
+	}
+	if count == 0 {
+		count = len(data)
+	}
+
+	sum := 0.0
+
+	for i := 0; i < count; i++ {
+		sum += data[i].Volume
+		ret = ret.WithRow(
+			data[i].Time.Format("2006-01-02"),
+			fmt.Sprintf("%.4f", data[i].Volume/1000000000),
+		)
+	}
+	ret = ret.WithFooter("SUM", fmt.Sprintf("%.4f", sum/1000000000))
+
+	return ret.String()
+}
+
+func (data DailyVolumes) FormatTable() string {
+	return data.FormatString(len(data))
+}
+
+func (data DailyVolumes) Header() []string {
+	return []string{"date", "daily_usd_volume"}
+}
+
+func (data DailyVolumes) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, item := range data {
+		records = append(records, []string{item.Time.Format("2006-01-02"), common.FormatFloat(item.Volume)})
+	}
+
+	return records
+}
@@ -0,0 +1,54 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// DailyVolumeByUsers renders hlstats.DailyVolumeByUsers.
+type DailyVolumeByUsers hlstats.DailyVolumeByUsers
+
+func (data DailyVolumeByUsers) FormatString(count int) string {
+	ret := common.NewTableFormatter().WithHeader("Daily Volume By User")
+	ret = ret.WithHeader("Date", "User", "Volume ($M USD)")
+
+	if count > len(data) {
+		count = len(data)
+	}
+	if count == 0 {
+		count = len(data)
+	}
+
+	for i := 0; i < count; i++ {
+		ret = ret.WithRow(
+			data[i].Time.Format("2006-01-02"),
+			data[i].User,
+			fmt.Sprintf("%.4f", data[i].Volume/1000000),
+		)
+	}
+
+	return ret.String()
+}
+
+func (data DailyVolumeByUsers) FormatTable() string {
+	return data.FormatString(len(data))
+}
+
+func (data DailyVolumeByUsers) Header() []string {
+	return []string{"date", "user", "daily_usd_volume"}
+}
+
+func (data DailyVolumeByUsers) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, item := range data {
+		records = append(records, []string{
+			item.Time.Format("2006-01-02"),
+			item.User,
+			common.FormatFloat(item.Volume),
+		})
+	}
+
+	return records
+}
@@ -0,0 +1,134 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+)
+
+// VaultDiff renders snapshot.VaultDiff as one titled table per kind of change. CSV output has one
+// row per change, with the kind of change in the first column.
+type VaultDiff snapshot.VaultDiff
+
+// formatTVLChange formats a TVL change in $M with an explicit sign.
+func formatTVLChange(change float64) string {
+	return fmt.Sprintf("%+.3f", change/1000000)
+}
+
+func (d VaultDiff) FormatTable() string {
+	newVaults := common.NewTableFormatter().WithHeader("Name", "Address", "Leader", "TVL")
+	for _, record := range d.New {
+		newVaults = newVaults.WithRow(record.Name, record.Address, record.Leader, fmt.Sprintf("%.3f", record.TVL/1000000))
+	}
+
+	removed := common.NewTableFormatter().WithHeader("Name", "Address", "Leader", "TVL")
+	for _, record := range d.Removed {
+		removed = removed.WithRow(record.Name, record.Address, record.Leader, fmt.Sprintf("%.3f", record.TVL/1000000))
+	}
+
+	closed := common.NewTableFormatter().WithHeader("Name", "Address", "Leader", "TVL")
+	for _, record := range d.Closed {
+		closed = closed.WithRow(record.Name, record.Address, record.Leader, fmt.Sprintf("%.3f", record.TVL/1000000))
+	}
+
+	tvlChanges := func(changes []snapshot.TVLChange) string {
+		ret := common.NewTableFormatter().WithHeader("Name", "Address", "From", "To", "Change", "Percent")
+		for _, change := range changes {
+			percent := "-"
+			if change.From != 0 {
+				percent = fmt.Sprintf("%+.2f%%", change.Percent*100)
+			}
+			ret = ret.WithRow(
+				change.Name,
+				change.Address,
+				fmt.Sprintf("%.3f", change.From/1000000),
+				fmt.Sprintf("%.3f", change.To/1000000),
+				formatTVLChange(change.Change),
+				percent,
+			)
+		}
+		return ret.WithCaption("TVL is in $M").String()
+	}
+
+	leaders := common.NewTableFormatter().WithHeader("Name", "Address", "From", "To")
+	for _, change := range d.Leaders {
+		leaders = leaders.WithRow(change.Name, change.Address, change.From, change.To)
+	}
+
+	result := fmt.Sprintf("Vaults from %s to %s\n\n",
+		d.From.Local().Format(snapshotTimeLayout), d.To.Local().Format(snapshotTimeLayout)) +
+		"New vaults\n" + newVaults.String() + "\n" +
+		"Removed vaults\n" + removed.String() + "\n" +
+		"Closed vaults\n" + closed.String() + "\n" +
+		"Largest TVL changes\n" + tvlChanges(d.TVLByAmount) + "\n" +
+		"Largest relative TVL changes\n" + tvlChanges(d.TVLByPercent) + "\n" +
+		"Leader changes\n" + leaders.String()
+
+	if d.VolumePeriod != "" {
+		ranks := common.NewTableFormatter().WithHeader("Name", "Address", "From", "To", "Move", "Volume")
+		for _, move := range d.VolumeRanks {
+			ranks = ranks.WithRow(
+				move.Name,
+				move.Address,
+				fmt.Sprintf("#%d", move.From),
+				fmt.Sprintf("#%d", move.To),
+				fmt.Sprintf("%+d", move.Move),
+				fmt.Sprintf("%.3f", move.Volume/1000000),
+			)
+		}
+		ranks = ranks.WithCaption("Volume is in $M; ranks only count the vaults in both snapshots")
+		result += fmt.Sprintf("\nVolume rank movers (%s, %s)\n", d.VolumePeriod, d.VolumeMarket) + ranks.String()
+	}
+
+	return result
+}
+
+func (d VaultDiff) Header() []string {
+	return []string{"change", "address", "name", "from", "to", "delta", "percent"}
+}
+
+func (d VaultDiff) Records() [][]string {
+	var records [][]string
+	for _, record := range d.New {
+		records = append(records, []string{"new", record.Address, record.Name, "", common.FormatFloat(record.TVL), "", ""})
+	}
+	for _, record := range d.Removed {
+		records = append(records, []string{"removed", record.Address, record.Name, common.FormatFloat(record.TVL), "", "", ""})
+	}
+	for _, record := range d.Closed {
+		records = append(records, []string{"closed", record.Address, record.Name, "", common.FormatFloat(record.TVL), "", ""})
+	}
+	tvlChanges := func(kind string, changes []snapshot.TVLChange) {
+		for _, change := range changes {
+			records = append(records, []string{
+				kind,
+				change.Address,
+				change.Name,
+				common.FormatFloat(change.From),
+				common.FormatFloat(change.To),
+				common.FormatFloat(change.Change),
+				common.FormatFloat(change.Percent),
+			})
+		}
+	}
+	tvlChanges("tvl", d.TVLByAmount)
+	tvlChanges("tvl_relative", d.TVLByPercent)
+	for _, change := range d.Leaders {
+		records = append(records, []string{"leader", change.Address, change.Name, change.From, change.To, "", ""})
+	}
+	for _, move := range d.VolumeRanks {
+		records = append(records, []string{
+			"volume_rank",
+			move.Address,
+			move.Name,
+			strconv.Itoa(move.From),
+			strconv.Itoa(move.To),
+			strconv.Itoa(move.Move),
+			"",
+		})
+	}
+
+	return records
+}
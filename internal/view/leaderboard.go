@@ -0,0 +1,119 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+)
+
+// RankedUsers renders a leaderboard in machine-readable formats. The change columns are empty
+// unless the leaderboard was compared with a previous snapshot.
+type RankedUsers snapshot.RankedUsers
+
+// LeaderboardReport renders a leaderboard of the given kind as a table, compared with the snapshot
+// taken at Since unless Since is zero.
+type LeaderboardReport struct {
+	Kind  snapshot.Kind `json:"kind"`
+	Since time.Time     `json:"since"`
+	Users RankedUsers   `json:"users"`
+}
+
+func (data RankedUsers) FormatTable() string {
+	return LeaderboardReport{Kind: snapshot.KindLargestUsers, Users: data}.FormatTable()
+}
+
+func (data RankedUsers) Header() []string {
+	return []string{"rank", "user", "value", "previous_rank", "previous_value", "rank_change", "value_change", "status"}
+}
+
+func (data RankedUsers) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, user := range data {
+		previousRank, previousValue, rankChange, valueChange := "", "", "", ""
+		if user.PreviousRank > 0 {
+			previousRank = strconv.Itoa(user.PreviousRank)
+			previousValue = common.FormatFloat(user.PreviousValue)
+		}
+		if user.Rank > 0 && user.PreviousRank > 0 {
+			rankChange = strconv.Itoa(user.RankChange())
+			valueChange = common.FormatFloat(user.ValueChange())
+		}
+		records = append(records, []string{
+			strconv.Itoa(user.Rank),
+			user.Name,
+			common.FormatFloat(user.Value),
+			previousRank,
+			previousValue,
+			rankChange,
+			valueChange,
+			string(user.Status),
+		})
+	}
+
+	return records
+}
+
+func (r LeaderboardReport) FormatTable() string {
+	valueHeader, caption := "Volume", "Volume is in $M"
+	formatValue := func(v float64) string {
+		return fmt.Sprintf("%.4f", v/1000000)
+	}
+	if r.Kind == snapshot.KindTradeCounts {
+		valueHeader, caption = "Trades", ""
+		formatValue = func(v float64) string {
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+
+	ret := common.NewTableFormatter()
+	if r.Since.IsZero() {
+		ret = ret.WithHeader("Rank", "User", valueHeader)
+		for _, user := range r.Users {
+			ret = ret.WithRow(fmt.Sprintf("#%d", user.Rank), user.Name, formatValue(user.Value))
+		}
+	} else {
+		ret = ret.WithHeader("Rank", "User", valueHeader, "ΔRank", "Δ"+valueHeader)
+		for _, user := range r.Users {
+			switch user.Status {
+			case snapshot.RankNew:
+				ret = ret.WithRow(fmt.Sprintf("#%d", user.Rank), user.Name, formatValue(user.Value), "NEW", "-")
+			case snapshot.RankDropped:
+				ret = ret.WithRow("-", user.Name, "-", fmt.Sprintf("dropped out (#%d)", user.PreviousRank), "-")
+			default:
+				rankChange := "="
+				if change := user.RankChange(); change != 0 {
+					rankChange = fmt.Sprintf("%+d", change)
+				}
+				valueChange := formatValue(user.ValueChange())
+				if user.ValueChange() >= 0 {
+					valueChange = "+" + valueChange
+				}
+				ret = ret.WithRow(fmt.Sprintf("#%d", user.Rank), user.Name, formatValue(user.Value), rankChange, valueChange)
+			}
+		}
+
+		compared := "Compared with the snapshot of " + r.Since.Local().Format(snapshotTimeLayout)
+		if caption == "" {
+			caption = compared
+		} else {
+			caption = compared + "; " + strings.ToLower(caption[:1]) + caption[1:]
+		}
+	}
+	if caption != "" {
+		ret = ret.WithCaption(caption)
+	}
+
+	return ret.String()
+}
+
+func (r LeaderboardReport) Header() []string {
+	return r.Users.Header()
+}
+
+func (r LeaderboardReport) Records() [][]string {
+	return r.Users.Records()
+}
@@ -0,0 +1,162 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+)
+
+// snapshotTimeLayout is the layout of snapshot times in tables.
+const snapshotTimeLayout = "2006-01-02 15:04:05"
+
+// SnapshotInfos renders a list of snapshot summaries.
+type SnapshotInfos []snapshot.Info
+
+func (data SnapshotInfos) FormatTable() string {
+	ret := common.NewTableFormatter().WithHeader("Snapshots")
+	ret = ret.WithHeader("Time", "Network", "Kind", "Records", "Partial")
+
+	for _, info := range data {
+		partial := ""
+		if info.Partial {
+			partial = "yes"
+		}
+		ret = ret.WithRow(
+			info.Time.Local().Format(snapshotTimeLayout),
+			info.Network,
+			string(info.Kind),
+			strconv.Itoa(info.Records),
+			partial,
+		)
+	}
+	ret = ret.WithCaption(fmt.Sprintf("%d snapshots", len(data)))
+
+	return ret.String()
+}
+
+func (data SnapshotInfos) Header() []string {
+	return []string{"time", "network", "kind", "records", "partial"}
+}
+
+func (data SnapshotInfos) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, info := range data {
+		records = append(records, []string{
+			formatTime(info.Time),
+			info.Network,
+			string(info.Kind),
+			strconv.Itoa(info.Records),
+			strconv.FormatBool(info.Partial),
+		})
+	}
+
+	return records
+}
+
+// VaultHistory renders the recorded states of a vault.
+type VaultHistory []snapshot.VaultPoint
+
+func (data VaultHistory) FormatTable() string {
+	ret := common.NewTableFormatter().WithHeader("Time", "TVL", "APR", "Day Vol", "Week Vol", "Month Vol", "All Time Vol")
+
+	for _, p := range data {
+		day, week, month, allTime := "-", "-", "-", "-"
+		if p.Volume != nil {
+			day = fmt.Sprintf("%.3f", p.Volume.Day/1000000)
+			week = fmt.Sprintf("%.3f", p.Volume.Week/1000000)
+			month = fmt.Sprintf("%.3f", p.Volume.Month/1000000)
+			allTime = fmt.Sprintf("%.3f", p.Volume.AllTime/1000000)
+		}
+		ret = ret.WithRow(
+			p.Time.Local().Format(snapshotTimeLayout),
+			fmt.Sprintf("%.3f", p.TVL/1000000),
+			fmt.Sprintf("%.2f%%", p.APR*100),
+			day, week, month, allTime,
+		)
+	}
+	caption := "TVL and volumes are in $M; volumes are only recorded by vault-volumes snapshots"
+	if len(data) > 0 {
+		caption = fmt.Sprintf("%s: %s", data[len(data)-1].Name, caption)
+	}
+	ret = ret.WithCaption(caption)
+
+	return ret.String()
+}
+
+func (data VaultHistory) Header() []string {
+	return []string{"time", "name", "tvl", "apr", "closed", "day", "week", "month", "all_time"}
+}
+
+func (data VaultHistory) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, p := range data {
+		day, week, month, allTime := "", "", "", ""
+		if p.Volume != nil {
+			day = common.FormatFloat(p.Volume.Day)
+			week = common.FormatFloat(p.Volume.Week)
+			month = common.FormatFloat(p.Volume.Month)
+			allTime = common.FormatFloat(p.Volume.AllTime)
+		}
+		records = append(records, []string{
+			formatTime(p.Time),
+			p.Name,
+			common.FormatFloat(p.TVL),
+			common.FormatFloat(p.APR),
+			strconv.FormatBool(p.Closed),
+			day, week, month, allTime,
+		})
+	}
+
+	return records
+}
+
+// UserHistory renders the recorded leaderboard positions of a user.
+type UserHistory []snapshot.UserPoint
+
+// formatRank formats a leaderboard rank, 0 meaning not ranked.
+func formatRank(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+
+	return strconv.Itoa(rank)
+}
+
+func (data UserHistory) FormatTable() string {
+	ret := common.NewTableFormatter().WithHeader("User History")
+	ret = ret.WithHeader("Time", "Volume Rank", "Volume", "Trade Rank", "Trades")
+
+	for _, p := range data {
+		ret = ret.WithRow(
+			p.Time.Local().Format(snapshotTimeLayout),
+			formatRank(p.VolumeRank),
+			fmt.Sprintf("%.3f", p.Volume/1000000),
+			formatRank(p.TradeRank),
+			fmt.Sprintf("%.0f", p.Trades),
+		)
+	}
+	ret = ret.WithCaption("Volume is in $M; a rank of - means not on the leaderboard")
+
+	return ret.String()
+}
+
+func (data UserHistory) Header() []string {
+	return []string{"time", "volume_rank", "volume", "trade_rank", "trades"}
+}
+
+func (data UserHistory) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, p := range data {
+		records = append(records, []string{
+			formatTime(p.Time),
+			strconv.Itoa(p.VolumeRank),
+			common.FormatFloat(p.Volume),
+			strconv.Itoa(p.TradeRank),
+			common.FormatFloat(p.Trades),
+		})
+	}
+
+	return records
+}
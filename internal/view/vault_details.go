@@ -0,0 +1,120 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultDetails renders hlstats.VaultDetails.
+type VaultDetails hlstats.VaultDetails
+
+func (d VaultDetails) FormatTable() string {
+	var result strings.Builder
+
+	overview := common.NewTableFormatter().WithHeader("Field", "Value")
+	overview = overview.WithRow("Name", d.Name)
+	overview = overview.WithRow("Address", d.Address)
+	overview = overview.WithRow("Leader", d.Leader)
+	overview = overview.WithRow("Status", vaultStatus(d.Closed))
+	overview = overview.WithRow("Relationship", formatRelationship(d.Relationship))
+	overview = overview.WithRow("APR", fmt.Sprintf("%.2f%%", d.APR*100))
+	overview = overview.WithRow("Followers", len(d.Followers))
+	overview = overview.WithRow("Leader Fraction", fmt.Sprintf("%.2f%%", d.LeaderFraction*100))
+	overview = overview.WithRow("Leader Commission", fmt.Sprintf("%.2f%%", d.LeaderCommission*100))
+	overview = overview.WithRow("Max Distributable", fmt.Sprintf("%.2f", d.MaxDistributable))
+	overview = overview.WithRow("Max Withdrawable", fmt.Sprintf("%.2f", d.MaxWithdrawable))
+	overview = overview.WithRow("Allow Deposits", d.AllowDeposits)
+	overview = overview.WithRow("Always Close On Withdraw", d.AlwaysCloseOnWithdraw)
+	if d.Description != "" {
+		overview = overview.WithCaption(d.Description)
+	}
+	result.WriteString(overview.String())
+	result.WriteString("\n")
+
+	portfolio := common.NewTableFormatter().WithHeader("Period", "Volume", "Account Value", "PNL", "Points")
+	for _, name := range hlstats.PortfolioPeriods {
+		period := d.Portfolio.Period(name)
+		portfolio = portfolio.WithRow(
+			name,
+			fmt.Sprintf("%.2f", period.Volume),
+			fmt.Sprintf("%.2f", period.AccountValueHistory.Last()),
+			fmt.Sprintf("%.2f", period.PnLHistory.Last()),
+			len(period.AccountValueHistory),
+		)
+	}
+	portfolio = portfolio.WithCaption("Portfolio: latest account value and PnL of each period")
+	result.WriteString(portfolio.String())
+
+	return result.String()
+}
+
+// Header returns the columns of the portfolio time series, one row per sample.
+func (d VaultDetails) Header() []string {
+	return []string{"period", "time", "account_value", "pnl"}
+}
+
+// Records returns the account value and PnL samples of every period. Samples are joined on their
+// timestamp; a value missing from one of the series is left empty.
+func (d VaultDetails) Records() [][]string {
+	var records [][]string
+	for _, name := range hlstats.PortfolioPeriods {
+		period := d.Portfolio.Period(name)
+
+		pnl := make(map[time.Time]float64, len(period.PnLHistory))
+		for _, point := range period.PnLHistory {
+			pnl[point.Time] = point.Value
+		}
+
+		seen := make(map[time.Time]bool, len(period.AccountValueHistory))
+		for _, point := range period.AccountValueHistory {
+			seen[point.Time] = true
+			pnlValue := ""
+			if v, ok := pnl[point.Time]; ok {
+				pnlValue = common.FormatFloat(v)
+			}
+			records = append(records, []string{
+				name,
+				point.Time.Format(time.RFC3339),
+				common.FormatFloat(point.Value),
+				pnlValue,
+			})
+		}
+		for _, point := range period.PnLHistory {
+			if !seen[point.Time] {
+				records = append(records, []string{
+					name,
+					point.Time.Format(time.RFC3339),
+					"",
+					common.FormatFloat(point.Value),
+				})
+			}
+		}
+	}
+
+	return records
+}
+
+func vaultStatus(closed bool) string {
+	if closed {
+		return "Closed"
+	}
+
+	return "Open"
+}
+
+func formatRelationship(r hlstats.VaultRelationship) string {
+	switch r.Type {
+	case hlstats.RelationshipParent:
+		return fmt.Sprintf("parent of %s", strings.Join(r.ChildAddresses, ", "))
+	case hlstats.RelationshipChild:
+		return fmt.Sprintf("child of %s", r.ParentAddress)
+	case "":
+		return "-"
+	default:
+		return r.Type
+	}
+}
@@ -0,0 +1,64 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultFetchErrors renders hlstats.VaultFetchErrors.
+type VaultFetchErrors hlstats.VaultFetchErrors
+
+// VaultVolumesReport pairs the fetched vault volumes with the vaults that failed. Market selects
+// the volumes shown, in the table and machine-readable output alike.
+type VaultVolumesReport struct {
+	Market   hlstats.Market   `json:"market"`
+	Vaults   VaultVolumesInfo `json:"vaults"`
+	Failures VaultFetchErrors `json:"failures"`
+}
+
+func (data VaultFetchErrors) FormatString() string {
+	ret := common.NewTableFormatter().WithHeader("Failed Vaults")
+	ret = ret.WithHeader("Address", "Name", "Attempts", "Status", "Error")
+
+	for _, item := range data {
+		status := "-"
+		if item.StatusCode != 0 {
+			status = fmt.Sprintf("%d", item.StatusCode)
+		}
+		ret = ret.WithRow(item.Address, item.Name, item.Attempts, status, item.Err)
+	}
+
+	return ret.String()
+}
+
+func (r VaultVolumesReport) FormatTable() string {
+	if len(r.Failures) == 0 {
+		return r.Vaults.FormatString(r.Market)
+	}
+
+	return r.Vaults.FormatString(r.Market) + "\n" + r.Failures.FormatString()
+}
+
+func (r VaultVolumesReport) Header() []string {
+	return MarketVaultVolumes{}.Header()
+}
+
+func (r VaultVolumesReport) Records() [][]string {
+	return NewMarketVaultVolumes(hlstats.VaultVolumesInfo(r.Vaults), r.Market).Records()
+}
+
+// MarshalJSON encodes the volumes of the selected market only.
+func (r VaultVolumesReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Market   hlstats.Market     `json:"market"`
+		Vaults   MarketVaultVolumes `json:"vaults"`
+		Failures VaultFetchErrors   `json:"failures"`
+	}{
+		Market:   r.Market,
+		Vaults:   NewMarketVaultVolumes(hlstats.VaultVolumesInfo(r.Vaults), r.Market),
+		Failures: r.Failures,
+	})
+}
@@ -0,0 +1,124 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultFollower is a depositor together with its share of the vault's total equity.
+type VaultFollower struct {
+	hlstats.VaultFollower
+	Share float64 `json:"share"`
+}
+
+// VaultFollowers renders depositors with their equity shares.
+type VaultFollowers []VaultFollower
+
+// NewVaultFollowers computes the share of every follower relative to totalEquity, which may
+// include depositors that are not listed.
+func NewVaultFollowers(followers hlstats.VaultFollowers, totalEquity float64) VaultFollowers {
+	result := make(VaultFollowers, 0, len(followers))
+	for _, f := range followers {
+		share := 0.0
+		if totalEquity > 0 {
+			share = f.VaultEquity / totalEquity
+		}
+		result = append(result, VaultFollower{VaultFollower: f, Share: share})
+	}
+
+	return result
+}
+
+// VaultFollowersReport pairs the depositors of a vault with their concentration.
+type VaultFollowersReport struct {
+	Address       string                        `json:"address"`
+	Name          string                        `json:"name"`
+	Leader        string                        `json:"leader"`
+	Concentration hlstats.FollowerConcentration `json:"concentration"`
+	Followers     VaultFollowers                `json:"followers"`
+}
+
+func (data VaultFollowers) FormatTable() string {
+	ret := common.NewTableFormatter().WithHeader("Vault Followers")
+	ret = ret.WithHeader("Rank", "User", "Equity", "Share", "PNL", "All Time PNL", "Days", "Lockup Until")
+
+	for i, f := range data {
+		lockup := "-"
+		if !f.LockupUntil.IsZero() {
+			lockup = f.LockupUntil.Format("2006-01-02")
+		}
+		ret = ret.WithRow(
+			fmt.Sprintf("#%d", i+1),
+			f.User,
+			fmt.Sprintf("%.2f", f.VaultEquity),
+			fmt.Sprintf("%.2f%%", f.Share*100),
+			fmt.Sprintf("%.2f", f.PnL),
+			fmt.Sprintf("%.2f", f.AllTimePnL),
+			f.DaysFollowing,
+			lockup,
+		)
+	}
+
+	return ret.String()
+}
+
+func (data VaultFollowers) Header() []string {
+	return []string{
+		"user", "vault_equity", "share", "pnl", "all_time_pnl",
+		"days_following", "vault_entry_time", "lockup_until",
+	}
+}
+
+func (data VaultFollowers) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, f := range data {
+		records = append(records, []string{
+			f.User,
+			common.FormatFloat(f.VaultEquity),
+			common.FormatFloat(f.Share),
+			common.FormatFloat(f.PnL),
+			common.FormatFloat(f.AllTimePnL),
+			strconv.Itoa(f.DaysFollowing),
+			formatTime(f.EntryTime),
+			formatTime(f.LockupUntil),
+		})
+	}
+
+	return records
+}
+
+func (r VaultFollowersReport) FormatTable() string {
+	c := r.Concentration
+
+	summary := common.NewTableFormatter().WithHeader("Metric", "Value")
+	summary = summary.WithRow("Vault", fmt.Sprintf("%s (%s)", r.Name, r.Address))
+	summary = summary.WithRow("Followers", c.Followers)
+	summary = summary.WithRow("Total Equity", fmt.Sprintf("%.2f", c.TotalEquity))
+	summary = summary.WithRow(fmt.Sprintf("Top %d Share", c.TopN), fmt.Sprintf("%.2f%%", c.TopNShare*100))
+	summary = summary.WithRow("HHI", fmt.Sprintf("%.4f", c.HHI))
+	summary = summary.WithRow("Leader Stake", fmt.Sprintf("%.2f (%.2f%%)", c.LeaderEquity, c.LeaderShare*100))
+	summary = summary.WithCaption("HHI is 1 for a single depositor")
+
+	return summary.String() + "\n" + r.Followers.FormatTable()
+}
+
+func (r VaultFollowersReport) Header() []string {
+	return r.Followers.Header()
+}
+
+func (r VaultFollowersReport) Records() [][]string {
+	return r.Followers.Records()
+}
+
+// formatTime formats t as RFC 3339 for machine-readable output. The zero time is empty.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
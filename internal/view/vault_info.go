@@ -0,0 +1,119 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// Vaults renders hlstats.Vaults.
+type Vaults hlstats.Vaults
+
+func (data Vaults) FormatString(count int) string {
+	ret := common.NewTableFormatter().WithHeader("Vaults (HLP First)")
+	ret = ret.WithHeader("Name", "Address", "TVL", "APR", "Age", "Type")
+
+	if count > len(data) {
+		count = len(data)
+	}
+
+	now := time.Now()
+
+	for i := 0; i < count; i++ {
+		vault := data[i]
+		vaultType := "Norm"
+		if vault.IsHLP() {
+			vaultType = "HLP"
+		}
+		name := vault.Data.Name
+		if len(name) > 20 {
+			name = fmt.Sprintf("%v....%v", name[:8], name[len(name)-8:])
+		}
+
+		age := "-"
+		if !vault.Data.CreateTime.IsZero() {
+			age = fmt.Sprintf("%dd", int(vault.Age(now).Hours()/24))
+		}
+
+		ret = ret.WithRow(
+			name,
+			vault.Data.Address,
+			fmt.Sprintf("%.2f", vault.Data.TVL),
+			fmt.Sprintf("%.2f%%", vault.APR*100),
+			age,
+			vaultType,
+		)
+	}
+
+	return ret.String()
+}
+
+func (data Vaults) FormatTable() string {
+	return data.FormatString(len(data))
+}
+
+func (data Vaults) Header() []string {
+	return []string{"name", "address", "leader", "tvl", "apr", "create_time", "relationship", "parent", "is_closed", "is_hlp"}
+}
+
+func (data Vaults) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, vault := range data {
+		records = append(records, []string{
+			vault.Data.Name,
+			vault.Data.Address,
+			vault.Data.Leader,
+			common.FormatFloat(vault.Data.TVL),
+			common.FormatFloat(vault.APR),
+			formatTime(vault.Data.CreateTime),
+			vault.Data.Relationship.Type,
+			vault.Data.Relationship.ParentAddress,
+			strconv.FormatBool(vault.Data.Closed),
+			strconv.FormatBool(vault.IsHLP()),
+		})
+	}
+
+	return records
+}
+
+// VaultSearchResults renders the vaults matching a search, open and closed.
+type VaultSearchResults hlstats.Vaults
+
+func (data VaultSearchResults) FormatTable() string {
+	ret := common.NewTableFormatter().WithHeader("Matching Vaults")
+	ret = ret.WithHeader("Name", "Address", "Leader", "TVL", "Status", "Type")
+
+	for _, vault := range data {
+		vaultType := "Norm"
+		if vault.IsHLP() {
+			vaultType = "HLP"
+		}
+		status := "Open"
+		if vault.Data.Closed {
+			status = "Closed"
+		}
+
+		ret = ret.WithRow(
+			vault.Data.Name,
+			vault.Data.Address,
+			vault.Data.Leader,
+			fmt.Sprintf("%.2f", vault.Data.TVL),
+			status,
+			vaultType,
+		)
+	}
+	ret = ret.WithCaption(fmt.Sprintf("%d matching vaults", len(data)))
+
+	return ret.String()
+}
+
+func (data VaultSearchResults) Header() []string {
+	return Vaults(data).Header()
+}
+
+func (data VaultSearchResults) Records() [][]string {
+	return Vaults(data).Records()
+}
@@ -0,0 +1,134 @@
+package view
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultPerformances renders hlstats.VaultPerformances. CSV output has one row per vault and period.
+type VaultPerformances hlstats.VaultPerformances
+
+// VaultPerformanceReport pairs the vault performances with the vaults that failed. Period selects
+// the period shown in the table output.
+type VaultPerformanceReport struct {
+	Period   string            `json:"period"`
+	Vaults   VaultPerformances `json:"vaults"`
+	Failures VaultFetchErrors  `json:"failures"`
+}
+
+// formatRatio formats a ratio for a table; undefined ratios are shown as "-".
+func formatRatio(r hlstats.Ratio) string {
+	switch {
+	case r.Undefined():
+		return "-"
+	case math.IsInf(float64(r), 1):
+		return "∞"
+	default:
+		return fmt.Sprintf("%.2f", r)
+	}
+}
+
+// formatRatioRecord formats a ratio for a record; undefined ratios are empty and +Inf is "+Inf".
+func formatRatioRecord(r hlstats.Ratio) string {
+	if r.Undefined() {
+		return ""
+	}
+
+	return common.FormatFloat(float64(r))
+}
+
+func (data VaultPerformances) FormatString(period string) string {
+	ret := common.NewTableFormatter().WithHeader(fmt.Sprintf("Vault Performance (%s)", period))
+	ret = ret.WithHeader("Rank", "Name", "Address", "Type", "TVL", "Return", "Max DD", "Volatility", "Sharpe", "Sortino", "PNL/Vol")
+
+	for i, vault := range data {
+		perf, err := hlstats.VaultPerformance(vault).Period(period)
+		if err != nil {
+			continue
+		}
+		vaultType := "Norm"
+		if vault.IsHLP {
+			vaultType = "HLP"
+		}
+		name := vault.Name
+		if len(name) > 20 {
+			name = fmt.Sprintf("%v....%v", name[:8], name[len(name)-8:])
+		}
+
+		ret = ret.WithRow(
+			fmt.Sprintf("#%d", i+1),
+			name,
+			vault.Address,
+			vaultType,
+			fmt.Sprintf("%.3f", vault.TVL/1000000),
+			fmt.Sprintf("%.2f%%", perf.Return*100),
+			fmt.Sprintf("%.2f%%", perf.MaxDrawdown*100),
+			fmt.Sprintf("%.2f%%", perf.Volatility*100),
+			formatRatio(perf.Sharpe),
+			formatRatio(perf.Sortino),
+			fmt.Sprintf("%.5f", perf.PnLToVolume),
+		)
+	}
+	ret = ret.WithCaption("TVL is in $M; volatility, Sharpe and Sortino are annualised; - is undefined")
+
+	return ret.String()
+}
+
+func (data VaultPerformances) FormatTable() string {
+	return data.FormatString("month")
+}
+
+func (data VaultPerformances) Header() []string {
+	return []string{
+		"address", "name", "is_hlp", "tvl", "period",
+		"return", "max_drawdown", "volatility", "sharpe", "sortino",
+		"pnl", "volume", "pnl_to_volume", "samples",
+	}
+}
+
+func (data VaultPerformances) Records() [][]string {
+	records := make([][]string, 0, len(data)*len(hlstats.PerformancePeriods))
+	for _, vault := range data {
+		for _, period := range hlstats.PerformancePeriods {
+			perf, _ := hlstats.VaultPerformance(vault).Period(period)
+			records = append(records, []string{
+				vault.Address,
+				vault.Name,
+				strconv.FormatBool(vault.IsHLP),
+				common.FormatFloat(vault.TVL),
+				period,
+				common.FormatFloat(perf.Return),
+				common.FormatFloat(perf.MaxDrawdown),
+				common.FormatFloat(perf.Volatility),
+				formatRatioRecord(perf.Sharpe),
+				formatRatioRecord(perf.Sortino),
+				common.FormatFloat(perf.PnL),
+				common.FormatFloat(perf.Volume),
+				common.FormatFloat(perf.PnLToVolume),
+				strconv.Itoa(perf.Samples),
+			})
+		}
+	}
+
+	return records
+}
+
+func (r VaultPerformanceReport) FormatTable() string {
+	if len(r.Failures) == 0 {
+		return r.Vaults.FormatString(r.Period)
+	}
+
+	return r.Vaults.FormatString(r.Period) + "\n" + r.Failures.FormatString()
+}
+
+func (r VaultPerformanceReport) Header() []string {
+	return r.Vaults.Header()
+}
+
+func (r VaultPerformanceReport) Records() [][]string {
+	return r.Vaults.Records()
+}
@@ -0,0 +1,121 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultTree renders hlstats.VaultTree. Table and CSV rows list every parent followed by its children.
+type VaultTree hlstats.VaultTree
+
+// VaultTreeReport pairs the vault tree with the vaults that failed.
+type VaultTreeReport struct {
+	Vaults   VaultTree        `json:"vaults"`
+	Failures VaultFetchErrors `json:"failures"`
+}
+
+// walk calls fn for every node, parents before their children.
+func (t VaultTree) walk(fn func(node *hlstats.VaultNode, parent *hlstats.VaultNode, last bool)) {
+	for _, root := range t {
+		fn(root, nil, false)
+		for i, child := range root.Children {
+			fn(child, root, i == len(root.Children)-1)
+		}
+	}
+}
+
+func (t VaultTree) FormatTable() string {
+	ret := common.NewTableFormatter().WithHeader("Vault Tree")
+	ret = ret.WithHeader("Vault", "Address", "Type", "TVL", "Day Vol", "Week Vol", "Month Vol", "All Time Vol", "Month PNL", "All Time PNL")
+
+	t.walk(func(node *hlstats.VaultNode, parent *hlstats.VaultNode, last bool) {
+		name := node.Name
+		if name == "" {
+			name = "-"
+		}
+		if parent != nil {
+			branch := "├─ "
+			if last {
+				branch = "└─ "
+			}
+			name = branch + name
+		}
+		vaultType := "Norm"
+		if node.IsHLP {
+			vaultType = "HLP"
+		}
+		f := node.Total
+		ret = ret.WithRow(
+			name,
+			node.Address,
+			vaultType,
+			fmt.Sprintf("%.3f", f.TVL/1000000),
+			fmt.Sprintf("%.3f", f.Volume.Day/1000000),
+			fmt.Sprintf("%.3f", f.Volume.Week/1000000),
+			fmt.Sprintf("%.3f", f.Volume.Month/1000000),
+			fmt.Sprintf("%.3f", f.Volume.AllTime/1000000),
+			fmt.Sprintf("%.3f", f.PnL.Month/1000000),
+			fmt.Sprintf("%.3f", f.PnL.AllTime/1000000),
+		)
+	})
+	ret = ret.WithCaption("Values are in $M; parent rows are rolled up from their children")
+
+	return ret.String()
+}
+
+func (t VaultTree) Header() []string {
+	return []string{
+		"address", "name", "parent", "is_hlp", "children",
+		"tvl", "day", "week", "month", "all_time",
+		"pnl_day", "pnl_week", "pnl_month", "pnl_all_time",
+	}
+}
+
+// Records returns the rolled-up figures of every node.
+func (t VaultTree) Records() [][]string {
+	var records [][]string
+	t.walk(func(node *hlstats.VaultNode, parent *hlstats.VaultNode, last bool) {
+		parentAddress := ""
+		if parent != nil {
+			parentAddress = parent.Address
+		}
+		f := node.Total
+		records = append(records, []string{
+			node.Address,
+			node.Name,
+			parentAddress,
+			strconv.FormatBool(node.IsHLP),
+			strconv.Itoa(len(node.Children)),
+			common.FormatFloat(f.TVL),
+			common.FormatFloat(f.Volume.Day),
+			common.FormatFloat(f.Volume.Week),
+			common.FormatFloat(f.Volume.Month),
+			common.FormatFloat(f.Volume.AllTime),
+			common.FormatFloat(f.PnL.Day),
+			common.FormatFloat(f.PnL.Week),
+			common.FormatFloat(f.PnL.Month),
+			common.FormatFloat(f.PnL.AllTime),
+		})
+	})
+
+	return records
+}
+
+func (r VaultTreeReport) FormatTable() string {
+	if len(r.Failures) == 0 {
+		return r.Vaults.FormatTable()
+	}
+
+	return r.Vaults.FormatTable() + "\n" + r.Failures.FormatString()
+}
+
+func (r VaultTreeReport) Header() []string {
+	return r.Vaults.Header()
+}
+
+func (r VaultTreeReport) Records() [][]string {
+	return r.Vaults.Records()
+}
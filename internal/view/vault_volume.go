@@ -0,0 +1,298 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultVolume renders a single hlstats.VaultVolume.
+type VaultVolume hlstats.VaultVolume
+
+// VaultVolumesInfo renders hlstats.VaultVolumesInfo.
+type VaultVolumesInfo hlstats.VaultVolumesInfo
+
+// FormatSummary aggregates the volumes of the given market by HLP status and lists the top 10
+// vaults by TVL. The aggregates only cover the given vaults, so they are not the totals of all
+// vaults once the listing was filtered or paged.
+func (data VaultVolumesInfo) FormatSummary(market hlstats.Market) string {
+	// Aggregate volumes by HLP status
+	hlpTotals := struct {
+		Day     float64
+		Week    float64
+		Month   float64
+		AllTime float64
+		TVL     float64
+		Count   int
+	}{}
+
+	nonHLPTotals := struct {
+		Day     float64
+		Week    float64
+		Month   float64
+		AllTime float64
+		TVL     float64
+		Count   int
+	}{}
+
+	// Calculate totals
+	for _, vault := range data {
+		volume := vault.Volume.ForMarket(market)
+		if vault.IsHLP {
+			hlpTotals.Day += volume.Day
+			hlpTotals.Week += volume.Week
+			hlpTotals.Month += volume.Month
+			hlpTotals.AllTime += volume.AllTime
+			hlpTotals.TVL += vault.TVL
+			hlpTotals.Count++
+		} else {
+			nonHLPTotals.Day += volume.Day
+			nonHLPTotals.Week += volume.Week
+			nonHLPTotals.Month += volume.Month
+			nonHLPTotals.AllTime += volume.AllTime
+			nonHLPTotals.TVL += vault.TVL
+			nonHLPTotals.Count++
+		}
+	}
+
+	var result strings.Builder
+
+	// HLP Totals Section
+	result.WriteString("=== VAULT VOLUME SUMMARY ===\n\n")
+
+	hlpTable := common.NewTableFormatter().WithHeader("HLP Vaults Summary")
+	hlpTable = hlpTable.WithHeader("Metric", "Day", "Week", "Month", "All Time", "TVL")
+	hlpTable = hlpTable.WithRow(
+		fmt.Sprintf("HLP vaults shown (%d)", hlpTotals.Count),
+		fmt.Sprintf("%.3f", hlpTotals.Day/1000000),
+		fmt.Sprintf("%.3f", hlpTotals.Week/1000000),
+		fmt.Sprintf("%.3f", hlpTotals.Month/1000000),
+		fmt.Sprintf("%.3f", hlpTotals.AllTime/1000000),
+		fmt.Sprintf("%.3f", hlpTotals.TVL/1000000),
+	)
+	// The HLP parent vault is not fetched, as its figures duplicate those of its child vaults
+	hlpTable = hlpTable.WithCaption(fmt.Sprintf("HLP %s Volume Summary (Values are in $M; without the parent vault)", marketLabel(market)))
+	result.WriteString(hlpTable.String())
+	result.WriteString("\n")
+
+	// Non-HLP Totals Section
+	nonHLPTable := common.NewTableFormatter().WithHeader("Non-HLP Vaults Summary")
+	nonHLPTable = nonHLPTable.WithHeader("Metric", "Day", "Week", "Month", "All Time", "TVL")
+	nonHLPTable = nonHLPTable.WithRow(
+		fmt.Sprintf("Non-HLP vaults shown (%d)", nonHLPTotals.Count),
+		fmt.Sprintf("%.3f", nonHLPTotals.Day/1000000),
+		fmt.Sprintf("%.3f", nonHLPTotals.Week/1000000),
+		fmt.Sprintf("%.3f", nonHLPTotals.Month/1000000),
+		fmt.Sprintf("%.3f", nonHLPTotals.AllTime/1000000),
+		fmt.Sprintf("%.3f", nonHLPTotals.TVL/1000000),
+	)
+	nonHLPTable = nonHLPTable.WithCaption(fmt.Sprintf("Non-HLP %s Volume Summary (Values are in $M)", marketLabel(market)))
+	result.WriteString(nonHLPTable.String())
+	result.WriteString("\n")
+
+	// Top 10 TVL Section
+	topTVL := make(VaultVolumesInfo, len(data))
+	copy(topTVL, data)
+
+	// Sort by TVL descending (HLP first)
+	sort.SliceStable(topTVL, func(i, j int) bool {
+		if topTVL[i].IsHLP != topTVL[j].IsHLP {
+			return topTVL[i].IsHLP && !topTVL[j].IsHLP
+		}
+		return topTVL[i].TVL > topTVL[j].TVL
+	})
+
+	// Limit to top 10
+	displayCount := 10
+	if len(topTVL) < 10 {
+		displayCount = len(topTVL)
+	}
+
+	topTable := common.NewTableFormatter().WithHeader("Top 10 Vaults by TVL")
+	topTable = topTable.WithHeader("Rank", "Address", "Type", "TVL", "Day", "Week", "Month", "All Time")
+
+	for i := 0; i < displayCount; i++ {
+		vault := topTVL[i]
+		volume := vault.Volume.ForMarket(market)
+		vaultType := "Vault"
+		if vault.IsHLP {
+			vaultType = "HLP"
+		}
+		topTable = topTable.WithRow(
+			fmt.Sprintf("#%d", i+1),
+			vault.Address,
+			vaultType,
+			fmt.Sprintf("%.3f", vault.TVL/1000000),
+			fmt.Sprintf("%.3f", volume.Day/1000000),
+			fmt.Sprintf("%.3f", volume.Week/1000000),
+			fmt.Sprintf("%.3f", volume.Month/1000000),
+			fmt.Sprintf("%.3f", volume.AllTime/1000000),
+		)
+	}
+	topTable = topTable.WithCaption(fmt.Sprintf("%s volumes; values are in $M", marketLabel(market)))
+	result.WriteString(topTable.String())
+
+	return result.String()
+}
+
+// FormatString lists the volumes of the given market.
+func (data VaultVolumesInfo) FormatString(market hlstats.Market) string {
+	return formatMarketVolumes(NewMarketVaultVolumes(hlstats.VaultVolumesInfo(data), market), market)
+}
+
+// formatMarketVolumes lists the volumes of vaults in the given market.
+func formatMarketVolumes(data MarketVaultVolumes, market hlstats.Market) string {
+	ret := common.NewTableFormatter().WithHeader("Vault Volumes")
+	ret = ret.WithHeader("Address", "Type", "TVL", "Day", "Week", "Month", "All Time")
+
+	for _, vault := range data {
+		t := "Norm"
+		if vault.IsHLP {
+			t = "HLP"
+		}
+		ret = ret.WithRow(
+			vault.Address,
+			t,
+			fmt.Sprintf("%.3f", vault.TVL/1000000),
+			fmt.Sprintf("%.3f", vault.Volume.Day/1000000),
+			fmt.Sprintf("%.3f", vault.Volume.Week/1000000),
+			fmt.Sprintf("%.3f", vault.Volume.Month/1000000),
+			fmt.Sprintf("%.3f", vault.Volume.AllTime/1000000),
+		)
+	}
+	ret = ret.WithCaption(fmt.Sprintf("%s volumes; values are in $M", marketLabel(market)))
+
+	return ret.String()
+}
+
+func (data VaultVolumesInfo) FormatTable() string {
+	return data.FormatString(hlstats.MarketAll)
+}
+
+func (data VaultVolumesInfo) Header() []string {
+	return []string{
+		"address", "name", "is_hlp", "tvl",
+		"day", "week", "month", "all_time",
+		"perp_day", "perp_week", "perp_month", "perp_all_time",
+		"spot_day", "spot_week", "spot_month", "spot_all_time",
+	}
+}
+
+func (data VaultVolumesInfo) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, vault := range data {
+		records = append(records, []string{
+			vault.Address,
+			vault.Name,
+			strconv.FormatBool(vault.IsHLP),
+			common.FormatFloat(vault.TVL),
+			common.FormatFloat(vault.Volume.Day),
+			common.FormatFloat(vault.Volume.Week),
+			common.FormatFloat(vault.Volume.Month),
+			common.FormatFloat(vault.Volume.AllTime),
+			common.FormatFloat(vault.Volume.PerpDay),
+			common.FormatFloat(vault.Volume.PerpWeek),
+			common.FormatFloat(vault.Volume.PerpMonth),
+			common.FormatFloat(vault.Volume.PerpAllTime),
+			common.FormatFloat(vault.Volume.SpotDay),
+			common.FormatFloat(vault.Volume.SpotWeek),
+			common.FormatFloat(vault.Volume.SpotMonth),
+			common.FormatFloat(vault.Volume.SpotAllTime),
+		})
+	}
+
+	return records
+}
+
+// MarketVaultVolume is the volume of a vault in one market.
+type MarketVaultVolume struct {
+	Address string               `json:"address"`
+	Name    string               `json:"name"`
+	Parent  string               `json:"parent,omitempty"`
+	IsHLP   bool                 `json:"isHLP"`
+	TVL     float64              `json:"tvl"`
+	Market  hlstats.Market       `json:"market"`
+	Volume  hlstats.MarketVolume `json:"volume"`
+}
+
+// MarketVaultVolumes renders the volumes of vaults in one market, so that machine-readable output
+// carries the volumes shown in the table.
+type MarketVaultVolumes []MarketVaultVolume
+
+// NewMarketVaultVolumes selects the volumes of the given market.
+func NewMarketVaultVolumes(data hlstats.VaultVolumesInfo, market hlstats.Market) MarketVaultVolumes {
+	result := make(MarketVaultVolumes, 0, len(data))
+	for _, vault := range data {
+		result = append(result, MarketVaultVolume{
+			Address: vault.Address,
+			Name:    vault.Name,
+			Parent:  vault.Parent,
+			IsHLP:   vault.IsHLP,
+			TVL:     vault.TVL,
+			Market:  market,
+			Volume:  vault.Volume.ForMarket(market),
+		})
+	}
+
+	return result
+}
+
+func (data MarketVaultVolumes) FormatTable() string {
+	market := hlstats.MarketAll
+	if len(data) > 0 {
+		market = data[0].Market
+	}
+
+	return formatMarketVolumes(data, market)
+}
+
+func (data MarketVaultVolumes) Header() []string {
+	return []string{"address", "name", "is_hlp", "tvl", "market", "day", "week", "month", "all_time"}
+}
+
+func (data MarketVaultVolumes) Records() [][]string {
+	records := make([][]string, 0, len(data))
+	for _, vault := range data {
+		records = append(records, []string{
+			vault.Address,
+			vault.Name,
+			strconv.FormatBool(vault.IsHLP),
+			common.FormatFloat(vault.TVL),
+			string(vault.Market),
+			common.FormatFloat(vault.Volume.Day),
+			common.FormatFloat(vault.Volume.Week),
+			common.FormatFloat(vault.Volume.Month),
+			common.FormatFloat(vault.Volume.AllTime),
+		})
+	}
+
+	return records
+}
+
+func (v VaultVolume) FormatSingle(name, address string) string {
+	ret := common.NewTableFormatter().WithHeader(fmt.Sprintf("Vault Volume: %s", name))
+	ret = ret.WithHeader("Period", "Volume", "Perp Volume", "Spot Volume")
+
+	ret = ret.WithRow("Day", fmt.Sprintf("%.2f", v.Day), fmt.Sprintf("%.2f", v.PerpDay), fmt.Sprintf("%.2f", v.SpotDay))
+	ret = ret.WithRow("Week", fmt.Sprintf("%.2f", v.Week), fmt.Sprintf("%.2f", v.PerpWeek), fmt.Sprintf("%.2f", v.SpotWeek))
+	ret = ret.WithRow("Month", fmt.Sprintf("%.2f", v.Month), fmt.Sprintf("%.2f", v.PerpMonth), fmt.Sprintf("%.2f", v.SpotMonth))
+	ret = ret.WithRow("All Time", fmt.Sprintf("%.2f", v.AllTime), fmt.Sprintf("%.2f", v.PerpAllTime), fmt.Sprintf("%.2f", v.SpotAllTime))
+
+	return ret.String()
+}
+
+// marketLabel returns the display name of a market.
+func marketLabel(market hlstats.Market) string {
+	switch market {
+	case hlstats.MarketPerp:
+		return "Perp"
+	case hlstats.MarketSpot:
+		return "Spot"
+	default:
+		return "Total"
+	}
+}
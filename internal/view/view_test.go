@@ -0,0 +1,292 @@
+package view
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/snapshot"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestMain(m *testing.M) {
+	// Times are shown in local time
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// checkGolden compares got with the golden file testdata/name, or writes it with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run go test -update to accept it):\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
+
+var (
+	t0 = time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)
+	t1 = time.Date(2026, 10, 8, 8, 30, 0, 0, time.UTC)
+)
+
+func testVaults() hlstats.Vaults {
+	return hlstats.Vaults{
+		{
+			Data: hlstats.VaultSummary{Name: "Hyperliquidity Provider (HLP)", Address: "0xdf", Leader: "0x67", TVL: 1500000,
+				Relationship: hlstats.VaultRelationship{Type: hlstats.RelationshipParent, ChildAddresses: []string{"0xa1"}}},
+			APR: 0.1,
+			HLP: true,
+		},
+		{
+			Data: hlstats.VaultSummary{Name: "HLP Strategy A", Address: "0xa1", Leader: "0xdf", TVL: 1000000,
+				Relationship: hlstats.VaultRelationship{Type: hlstats.RelationshipChild, ParentAddress: "0xdf"}},
+			HLP: true,
+		},
+		{Data: hlstats.VaultSummary{Name: "A vault with a rather long name", Address: "0xb2", Leader: "0x3", TVL: 200000.5}, APR: 0.35},
+		{Data: hlstats.VaultSummary{Name: "Retired", Address: "0xd4", Leader: "0x5", Closed: true}},
+	}
+}
+
+func testVaultVolumes() hlstats.VaultVolumesInfo {
+	return hlstats.VaultVolumesInfo{
+		{Address: "0xdf", Name: "Hyperliquidity Provider (HLP)", IsHLP: true, TVL: 1500000,
+			Volume: hlstats.VaultVolume{Day: 2e6, Week: 1.4e7, Month: 6e7, AllTime: 9e8, PerpDay: 1.5e6, PerpWeek: 1e7, PerpMonth: 5e7, PerpAllTime: 8e8,
+				SpotDay: 5e5, SpotWeek: 4e6, SpotMonth: 1e7, SpotAllTime: 1e8}},
+		{Address: "0xb2", Name: "Alpha", TVL: 200000.5,
+			Volume: hlstats.VaultVolume{Day: 1000, Week: 7000, Month: 30000, AllTime: 500000, PerpDay: 1000, PerpWeek: 7000, PerpMonth: 30000, PerpAllTime: 500000}},
+	}
+}
+
+func testDetails() hlstats.VaultDetails {
+	point := func(at time.Time, v float64) hlstats.HistoryPoint { return hlstats.HistoryPoint{Time: at, Value: v} }
+	period := hlstats.PortfolioPeriod{
+		Volume:              5000,
+		AccountValueHistory: hlstats.History{point(t0, 1000), point(t1, 1080)},
+		PnLHistory:          hlstats.History{point(t0, 0), point(t1, 80)},
+	}
+
+	return hlstats.VaultDetails{
+		Name:        "Alpha",
+		Address:     "0xb2",
+		Leader:      "0x3",
+		Description: "Market making on majors",
+		Portfolio:   hlstats.VaultPortfolio{Day: period, Week: period, Month: period, AllTime: period, PerpWeek: period},
+		APR:         0.2,
+		Followers: hlstats.VaultFollowers{
+			{User: "Leader", VaultEquity: 250, PnL: 5, AllTimePnL: 20, DaysFollowing: 30, EntryTime: t0},
+			{User: "0xf1", VaultEquity: 750.5, PnL: -1, AllTimePnL: 2, DaysFollowing: 3, EntryTime: t0, LockupUntil: t1},
+		},
+		LeaderFraction:   0.25,
+		LeaderCommission: 0.1,
+		MaxDistributable: 1,
+		MaxWithdrawable:  2,
+		Relationship:     hlstats.VaultRelationship{Type: hlstats.RelationshipNormal},
+		AllowDeposits:    true,
+	}
+}
+
+func testFailures() VaultFetchErrors {
+	return VaultFetchErrors{
+		{Address: "0xc3", Name: "Beta", Attempts: 3, StatusCode: 500, Err: errors.New("api returned status 500")},
+		{Address: "0xe5", Name: "Gamma", Attempts: 1, Err: errors.New("context deadline exceeded")},
+	}
+}
+
+func testTree() hlstats.VaultTree {
+	child := func(address, name string, tvl, week float64) *hlstats.VaultNode {
+		figures := hlstats.VaultFigures{TVL: tvl, Volume: hlstats.VaultVolume{Day: week / 7, Week: week, Month: week * 4, AllTime: week * 50}, PnL: hlstats.VaultPnL{Month: tvl / 100, AllTime: tvl / 10}}
+		return &hlstats.VaultNode{Address: address, Name: name, IsHLP: true, Own: figures, Total: figures}
+	}
+	a, b := child("0xa1", "HLP Strategy A", 1e6, 7e6), child("0xe5", "HLP Liquidator", 5e5, 1.4e6)
+	root := &hlstats.VaultNode{Address: "0xdf", Name: "Hyperliquidity Provider (HLP)", IsHLP: true, Children: []*hlstats.VaultNode{a, b}}
+	root.Total.Add(a.Total)
+	root.Total.Add(b.Total)
+
+	return hlstats.VaultTree{root, child("0xb2", "", 2e5, 0)}
+}
+
+func testRanks() snapshot.RankedUsers {
+	previous := snapshot.NewLargestUsers("mainnet", t0, hlstats.USDVolumeByUsers{{Name: "0xu1", Value: 3e6}, {Name: "0xu2", Value: 2e6}, {Name: "0xu3", Value: 1e6}})
+	current := snapshot.NewLargestUsers("mainnet", t1, hlstats.USDVolumeByUsers{{Name: "0xu2", Value: 4e6}, {Name: "0xu1", Value: 3.5e6}, {Name: "0xu4", Value: 5e5}})
+
+	return snapshot.CompareRanks(previous, current)
+}
+
+func testDiff() snapshot.VaultDiff {
+	from := snapshot.NewVaults("mainnet", t0, hlstats.Vaults{
+		{Data: hlstats.VaultSummary{Name: "Alpha", Address: "0xb2", Leader: "0x3", TVL: 200000}},
+		{Data: hlstats.VaultSummary{Name: "Beta", Address: "0xc3", Leader: "0x4", TVL: 60000}},
+		{Data: hlstats.VaultSummary{Name: "Gamma", Address: "0xe5", Leader: "0x5", TVL: 50000}},
+		{Data: hlstats.VaultSummary{Name: "Epsilon", Address: "0xa7", Leader: "0x7", TVL: 40000}},
+	})
+	to := snapshot.NewVaults("mainnet", t1, hlstats.Vaults{
+		{Data: hlstats.VaultSummary{Name: "Alpha", Address: "0xb2", Leader: "0x9", TVL: 250000}},
+		{Data: hlstats.VaultSummary{Name: "Beta", Address: "0xc3", Leader: "0x4", TVL: 30000}},
+		{Data: hlstats.VaultSummary{Name: "Gamma", Address: "0xe5", Leader: "0x5", Closed: true}},
+		{Data: hlstats.VaultSummary{Name: "Delta", Address: "0xf6", Leader: "0x6", TVL: 1e6}},
+	})
+
+	volumes := func(at time.Time, alpha, beta float64) snapshot.Snapshot {
+		return snapshot.NewVaultVolumes("mainnet", at, hlstats.VaultVolumesInfo{
+			{Address: "0xb2", Name: "Alpha", Volume: hlstats.VaultVolume{Week: alpha}},
+			{Address: "0xc3", Name: "Beta", Volume: hlstats.VaultVolume{Week: beta}},
+		}, false)
+	}
+
+	diff := snapshot.CompareVaults(from, to, 10000)
+	if err := diff.CompareVolumes(volumes(t0, 1e6, 2e6), volumes(t1, 3e6, 1e6), "week", hlstats.MarketAll); err != nil {
+		panic(err)
+	}
+
+	return diff
+}
+
+func TestRenderers(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer common.Renderer
+	}{
+		{name: "daily_volume", renderer: DailyVolumes{{Time: t0, Volume: 1.5e9}, {Time: t1, Volume: 2.25e9}}},
+		{name: "daily_volume_by_user", renderer: DailyVolumeByUsers{{Time: t0, User: "0xu1", Volume: 1e6}, {Time: t0, User: "0xu2", Volume: 250.5}}},
+		{name: "vaults", renderer: Vaults(testVaults())},
+		{name: "vault_search", renderer: VaultSearchResults(testVaults())},
+		{name: "vault_details", renderer: VaultDetails(testDetails())},
+		{
+			name: "vault_followers",
+			renderer: VaultFollowersReport{
+				Address:       "0xb2",
+				Name:          "Alpha",
+				Leader:        "0x3",
+				Concentration: testDetails().FollowerConcentration(1),
+				Followers:     NewVaultFollowers(testDetails().Followers.SortByEquity(), testDetails().Followers.TotalEquity()),
+			},
+		},
+		{
+			name: "vault_performance",
+			renderer: VaultPerformanceReport{
+				Period:   "week",
+				Vaults:   VaultPerformances{hlstats.NewVaultPerformance(testVaults()[2], testDetails())},
+				Failures: testFailures(),
+			},
+		},
+		{name: "vault_tree", renderer: VaultTreeReport{Vaults: VaultTree(testTree()), Failures: testFailures()[:1]}},
+		{name: "vault_volumes", renderer: VaultVolumesReport{Market: hlstats.MarketPerp, Vaults: VaultVolumesInfo(testVaultVolumes())}},
+		{name: "vault_volumes_failures", renderer: VaultVolumesReport{Market: hlstats.MarketAll, Vaults: VaultVolumesInfo(testVaultVolumes()), Failures: testFailures()}},
+		{name: "leaderboard", renderer: RankedUsers(snapshot.Ranks(snapshot.NewTradeCounts("mainnet", t1, hlstats.LargestTradeCounts{{Name: "0xu1", Value: 10}, {Name: "0xu3", Value: 30}})))},
+		{name: "leaderboard_since", renderer: LeaderboardReport{Kind: snapshot.KindLargestUsers, Since: t0, Users: RankedUsers(testRanks())}},
+		{
+			name: "snapshots",
+			renderer: SnapshotInfos{
+				{Time: t0, Network: "mainnet", Kind: snapshot.KindVaults, Records: 4},
+				{Time: t1, Network: "mainnet", Kind: snapshot.KindVaultVolumes, Records: 2, Partial: true},
+			},
+		},
+		{
+			name: "vault_history",
+			renderer: VaultHistory{
+				{Time: t0, Name: "Alpha", TVL: 200000, APR: 0.3},
+				{Time: t1, Name: "Alpha", TVL: 250000, APR: 0.35, Volume: &hlstats.VaultVolume{Day: 1000, Week: 7000, Month: 30000, AllTime: 500000}},
+			},
+		},
+		{
+			name: "user_history",
+			renderer: UserHistory{
+				{Time: t0, VolumeRank: 3, Volume: 1e6},
+				{Time: t1, VolumeRank: 1, Volume: 4e6, TradeRank: 2, Trades: 30},
+			},
+		},
+		{name: "diff", renderer: VaultDiff(testDiff())},
+		{
+			name: "cache_stats",
+			renderer: CacheStats{
+				{Endpoint: "vaultDetails", Entries: 12, Expired: 2, Bytes: 3 << 20, Oldest: t0, Newest: t1},
+				{Endpoint: "vaults", Entries: 1, Bytes: 2048, Oldest: t1, Newest: t1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.renderer.Header()
+			for i, record := range tt.renderer.Records() {
+				if len(record) != len(header) {
+					t.Errorf("record %d has %d fields, the header %d", i, len(record), len(header))
+				}
+			}
+
+			for _, format := range []common.Format{common.FormatTable, common.FormatCSV} {
+				var buf bytes.Buffer
+				if err := common.Render(&buf, format, tt.renderer); err != nil {
+					t.Fatalf("Render(%s) error = %v", format, err)
+				}
+				checkGolden(t, tt.name+"."+string(format), buf.Bytes())
+			}
+		})
+	}
+}
+
+func TestFormatters(t *testing.T) {
+	volumes := VaultVolumesInfo(testVaultVolumes())
+	daily := DailyVolumes{{Time: t0, Volume: 1.5e9}, {Time: t1, Volume: 2.25e9}}
+
+	tests := []struct {
+		name string
+		got  string
+	}{
+		{name: "daily_volume_count", got: daily.FormatString(1)},
+		{name: "daily_volume_by_user_count", got: DailyVolumeByUsers{{Time: t0, User: "0xu1", Volume: 1e6}}.FormatString(5)},
+		{name: "vaults_count", got: Vaults(testVaults()).FormatString(2)},
+		{name: "vault_volumes_summary", got: volumes.FormatSummary(hlstats.MarketAll)},
+		{name: "vault_volumes_summary_spot", got: volumes.FormatSummary(hlstats.MarketSpot)},
+		{name: "vault_volume_single", got: VaultVolume(testVaultVolumes()[0].Volume).FormatSingle("HLP", "0xdf")},
+		{name: "vault_performance_day", got: VaultPerformances{hlstats.NewVaultPerformance(testVaults()[2], testDetails())}.FormatString("day")},
+		{name: "leaderboard_trades_since", got: LeaderboardReport{Kind: snapshot.KindTradeCounts, Since: t0, Users: RankedUsers(testRanks())}.FormatTable()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGolden(t, tt.name+".txt", []byte(tt.got))
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{n: 0, want: "0 B"},
+		{n: 1023, want: "1023 B"},
+		{n: 2048, want: "2.0 KiB"},
+		{n: 3 << 20, want: "3.0 MiB"},
+		{n: 5 << 30, want: "5.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
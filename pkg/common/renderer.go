@@ -0,0 +1,103 @@
+package common
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format is an output format supported by the CLI.
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Formats lists all supported output formats.
+var Formats = []Format{FormatTable, FormatJSON, FormatCSV, FormatNDJSON}
+
+// ParseFormat parses a case-insensitive format name. An empty name selects FormatTable.
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(s)))
+	if f == "" {
+		return FormatTable, nil
+	}
+	for _, supported := range Formats {
+		if f == supported {
+			return f, nil
+		}
+	}
+
+	return "", errors.Errorf("invalid format '%s'. Valid options: table, json, csv, ndjson", s)
+}
+
+// Renderer is implemented by every result type that can be printed in all output formats.
+// The JSON and NDJSON outputs encode the value itself, so machine formats always carry
+// raw (unscaled) numbers.
+type Renderer interface {
+	// FormatTable returns the human-readable table representation.
+	FormatTable() string
+	// Header returns the CSV column names.
+	Header() []string
+	// Records returns the CSV rows, one per item, with raw (unscaled) values.
+	Records() [][]string
+}
+
+// Render writes r to w in the given format.
+func Render(w io.Writer, format Format, r Renderer) error {
+	switch format {
+	case FormatTable, "":
+		_, err := fmt.Fprintln(w, r.FormatTable())
+		return err
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case FormatNDJSON:
+		return renderNDJSON(w, r)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(r.Header()); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(r.Records()); err != nil {
+			return err
+		}
+		return cw.Error()
+	default:
+		return errors.Errorf("unsupported format '%s'", format)
+	}
+}
+
+// renderNDJSON encodes each element of a slice renderer on its own line.
+// Non-slice renderers are encoded as a single line.
+func renderNDJSON(w io.Writer, r Renderer) error {
+	enc := json.NewEncoder(w)
+
+	v := reflect.ValueOf(r)
+	if v.Kind() != reflect.Slice {
+		return enc.Encode(r)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := enc.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatFloat formats a raw float for machine-readable output.
+func FormatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
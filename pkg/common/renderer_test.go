@@ -0,0 +1,182 @@
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// points is a Renderer over a slice, like the result types of the CLI.
+type points []struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+func (p points) FormatTable() string {
+	table := NewTableFormatter().WithHeader("Name", "Value")
+	for _, point := range p {
+		table = table.WithRow(point.Name, point.Value)
+	}
+	return table.String()
+}
+
+func (p points) Header() []string {
+	return []string{"name", "value"}
+}
+
+func (p points) Records() [][]string {
+	var records [][]string
+	for _, point := range p {
+		records = append(records, []string{point.Name, FormatFloat(point.Value)})
+	}
+	return records
+}
+
+// report is a Renderer over a struct.
+type report struct {
+	Total float64 `json:"total"`
+}
+
+func (r report) FormatTable() string {
+	return "Total: " + FormatFloat(r.Total)
+}
+
+func (r report) Header() []string {
+	return []string{"total"}
+}
+
+func (r report) Records() [][]string {
+	return [][]string{{FormatFloat(r.Total)}}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Format
+		wantErr bool
+	}{
+		{s: "", want: FormatTable},
+		{s: "table", want: FormatTable},
+		{s: " JSON ", want: FormatJSON},
+		{s: "Csv", want: FormatCSV},
+		{s: "ndjson", want: FormatNDJSON},
+		{s: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseFormat(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := points{{Name: "a, b", Value: 1.5}, {Name: "c", Value: 1e21}}
+
+	tests := []struct {
+		name     string
+		format   Format
+		renderer Renderer
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "csv",
+			format:   FormatCSV,
+			renderer: data,
+			want:     "name,value\n\"a, b\",1.5\nc,1000000000000000000000\n",
+		},
+		{
+			name:     "json",
+			format:   FormatJSON,
+			renderer: data[:1],
+			want:     "[\n  {\n    \"name\": \"a, b\",\n    \"value\": 1.5\n  }\n]\n",
+		},
+		{
+			name:     "ndjson slice",
+			format:   FormatNDJSON,
+			renderer: data,
+			want:     "{\"name\":\"a, b\",\"value\":1.5}\n{\"name\":\"c\",\"value\":1e+21}\n",
+		},
+		{
+			name:     "ndjson struct",
+			format:   FormatNDJSON,
+			renderer: report{Total: 2},
+			want:     "{\"total\":2}\n",
+		},
+		{
+			name:     "table",
+			format:   FormatTable,
+			renderer: report{Total: 0.25},
+			want:     "Total: 0.25\n",
+		},
+		{
+			name:     "default",
+			format:   "",
+			renderer: report{Total: 3},
+			want:     "Total: 3\n",
+		},
+		{name: "unsupported", format: "yaml", renderer: report{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Render(&buf, tt.format, tt.renderer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := buf.String(); err == nil && got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want string
+	}{
+		{v: 0, want: "0"},
+		{v: 1500000.5, want: "1500000.5"},
+		{v: -0.125, want: "-0.125"},
+		{v: 1e21, want: "1000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatFloat(tt.v); got != tt.want {
+			t.Errorf("FormatFloat(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestTableFormatter(t *testing.T) {
+	table := NewTableFormatter().
+		WithHeader("Name", "Value").
+		WithRows([]interface{}{"a", 1}, []interface{}{"b", 2.5}).
+		WithFooter("Total", "3.5").
+		WithCaption("Values are raw")
+
+	got := table.String()
+	for _, want := range []string{"NAME", "VALUE", "│ a ", "│ 2.5 ", "│ Total │", "3.5", "Values are raw"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() does not contain %q:\n%s", want, got)
+		}
+	}
+	if again := table.String(); again != got {
+		t.Errorf("String() is not stable:\n%s\n%s", got, again)
+	}
+
+	table.Flush()
+	table.WithHeader("Other").WithRow("x")
+	if got := table.String(); strings.Contains(got, "NAME") || !strings.Contains(got, "OTHER") {
+		t.Errorf("String() after Flush() = \n%s", got)
+	}
+}
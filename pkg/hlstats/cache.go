@@ -0,0 +1,374 @@
+package hlstats
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrCacheMiss is returned in CacheOffline mode for requests without a cached response.
+var ErrCacheMiss = errors.New("no cached response")
+
+// CacheMode selects how a Client uses its response cache.
+type CacheMode int
+
+const (
+	// CacheDefault serves cached responses younger than their TTL and caches fetched responses.
+	CacheDefault CacheMode = iota
+	// CacheRefresh fetches every response and caches it, replacing any cached response.
+	CacheRefresh
+	// CacheOffline serves cached responses of any age and fails with ErrCacheMiss otherwise; no
+	// request is sent.
+	CacheOffline
+)
+
+// DefaultCacheTTL is the TTL of the endpoints without an entry in the CacheTTLs.
+const DefaultCacheTTL = time.Minute
+
+// CacheTTLs maps an endpoint to how long its responses are served from the cache. POST requests to
+// the info endpoint are keyed by their "type" field (e.g. "vaultDetails"), GET requests by the last
+// element of the URL path (e.g. "daily_usd_volume" or "vaults"). A TTL of 0 disables caching.
+type CacheTTLs map[string]time.Duration
+
+// DefaultCacheTTLs returns the TTLs used by NewResponseCache: daily data is cached for hours, the
+// leaderboards for an hour and the vault listing and details for minutes.
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		"daily_usd_volume":             3 * time.Hour,
+		"daily_usd_volume_by_user":     3 * time.Hour,
+		"largest_users_by_usd_volume":  time.Hour,
+		"largest_users_by_trade_count": time.Hour,
+		"vaults":                       10 * time.Minute,
+		"vaultDetails":                 5 * time.Minute,
+	}
+}
+
+// ParseCacheTTLs parses TTLs given as "<endpoint>=<duration>", e.g. "vaultDetails=10m", on top of
+// the DefaultCacheTTLs.
+func ParseCacheTTLs(items []string) (CacheTTLs, error) {
+	ttls := DefaultCacheTTLs()
+	for _, item := range items {
+		parts := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid cache TTL '%s'. Expected format: <endpoint>=<duration>", item)
+		}
+
+		ttl, err := time.ParseDuration(parts[1])
+		if err != nil || ttl < 0 {
+			return nil, errors.Errorf("invalid duration in cache TTL '%s'", item)
+		}
+		ttls[parts[0]] = ttl
+	}
+
+	return ttls, nil
+}
+
+// TTL returns the TTL of the given endpoint.
+func (t CacheTTLs) TTL(endpoint string) time.Duration {
+	if ttl, ok := t[endpoint]; ok {
+		return ttl
+	}
+
+	return DefaultCacheTTL
+}
+
+// cacheEntry is a cached response, stored as one JSON file per request.
+type cacheEntry struct {
+	Method   string    `json:"method"`
+	URL      string    `json:"url"`
+	Endpoint string    `json:"endpoint"`
+	StoredAt time.Time `json:"storedAt"`
+	Body     []byte    `json:"body"`
+}
+
+// cacheExt is the extension of the cache entry files.
+const cacheExt = ".json"
+
+// ResponseCache is an on-disk cache of successful API responses, keyed by method, URL and payload.
+// Entries are written atomically, so several processes can share a cache directory.
+type ResponseCache struct {
+	dir  string
+	ttls CacheTTLs
+}
+
+// NewResponseCache opens the cache in dir, creating the directory if needed. Nil ttls select the
+// DefaultCacheTTLs.
+func NewResponseCache(dir string, ttls CacheTTLs) (*ResponseCache, error) {
+	if dir == "" {
+		return nil, errors.New("cache directory is not set")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create cache directory %s", dir)
+	}
+	if ttls == nil {
+		ttls = DefaultCacheTTLs()
+	}
+
+	return &ResponseCache{dir: dir, ttls: ttls}, nil
+}
+
+// Dir returns the directory of the cache.
+func (c *ResponseCache) Dir() string {
+	return c.dir
+}
+
+// TTLs returns the TTLs of the cache.
+func (c *ResponseCache) TTLs() CacheTTLs {
+	return c.ttls
+}
+
+// CacheStats summarises the entries of a ResponseCache.
+type CacheStats struct {
+	Endpoint string `json:"endpoint"`
+	Entries  int    `json:"entries"`
+	// Expired counts the entries older than the TTL of the endpoint.
+	Expired int       `json:"expired"`
+	Bytes   int64     `json:"bytes"`
+	Oldest  time.Time `json:"oldest"`
+	Newest  time.Time `json:"newest"`
+}
+
+// Stats summarises the entries of the cache per endpoint, ordered by endpoint.
+func (c *ResponseCache) Stats() ([]CacheStats, error) {
+	byEndpoint := make(map[string]*CacheStats)
+	err := c.walk(func(path string, info os.FileInfo, entry cacheEntry) error {
+		stats, ok := byEndpoint[entry.Endpoint]
+		if !ok {
+			stats = &CacheStats{Endpoint: entry.Endpoint, Oldest: entry.StoredAt, Newest: entry.StoredAt}
+			byEndpoint[entry.Endpoint] = stats
+		}
+
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if c.expired(entry, time.Now()) {
+			stats.Expired++
+		}
+		if entry.StoredAt.Before(stats.Oldest) {
+			stats.Oldest = entry.StoredAt
+		}
+		if entry.StoredAt.After(stats.Newest) {
+			stats.Newest = entry.StoredAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]CacheStats, 0, len(byEndpoint))
+	for _, stats := range byEndpoint {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Endpoint < result[j].Endpoint
+	})
+
+	return result, nil
+}
+
+// Clear removes the entries of the cache, or only the expired ones, and returns how many were removed.
+func (c *ResponseCache) Clear(expiredOnly bool) (int, error) {
+	removed := 0
+	err := c.walk(func(path string, info os.FileInfo, entry cacheEntry) error {
+		if expiredOnly && !c.expired(entry, time.Now()) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove cache entry %s", path)
+		}
+		removed++
+		return nil
+	})
+
+	return removed, err
+}
+
+// walk calls fn for every entry of the cache. Unreadable entries are passed with a zero entry, so
+// that they count as expired and can be cleared.
+func (c *ResponseCache) walk(fn func(path string, info os.FileInfo, entry cacheEntry) error) error {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read cache directory %s", c.dir)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != cacheExt {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(c.dir, file.Name())
+		var entry cacheEntry
+		if data, err := os.ReadFile(path); err == nil {
+			_ = json.Unmarshal(data, &entry)
+		}
+		if err := fn(path, info, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ResponseCache) expired(entry cacheEntry, now time.Time) bool {
+	return now.Sub(entry.StoredAt) >= c.ttls.TTL(entry.Endpoint)
+}
+
+// path returns the file of the entry with the given key.
+func (c *ResponseCache) path(key string) string {
+	return filepath.Join(c.dir, key+cacheExt)
+}
+
+// get returns the entry with the given key, and false if there is none or it cannot be read.
+func (c *ResponseCache) get(key string) (cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// put stores an entry under the given key, replacing any previous one.
+func (c *ResponseCache) put(key string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cache entry")
+	}
+
+	// Write to a temporary file first so that readers never see a partial entry
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create cache entry")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write cache entry")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write cache entry")
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to store cache entry")
+	}
+
+	return nil
+}
+
+// cacheKey returns the key of req: a hash of its method, URL and payload.
+func cacheKey(req *http.Request, payload []byte) string {
+	h := sha256.New()
+	io.WriteString(h, req.Method+"\n"+req.URL.String()+"\n")
+	h.Write(payload)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheEndpoint returns the CacheTTLs key of req.
+func cacheEndpoint(req *http.Request) string {
+	if req.Method == http.MethodGet || req.GetBody == nil {
+		return path.Base(req.URL.Path)
+	}
+
+	return requestType(req)
+}
+
+// cacheTransport is an http.RoundTripper serving responses from a ResponseCache. It wraps the
+// retryTransport, so cached responses bypass the limiter.
+type cacheTransport struct {
+	base  http.RoundTripper
+	cache *ResponseCache
+	mode  CacheMode
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := requestPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(req, payload)
+	endpoint := cacheEndpoint(req)
+	ttl := t.cache.ttls.TTL(endpoint)
+
+	switch t.mode {
+	case CacheOffline:
+		entry, ok := t.cache.get(key)
+		if !ok {
+			return nil, errors.Wrap(ErrCacheMiss, "offline")
+		}
+		return cachedResponse(req, entry), nil
+	case CacheDefault:
+		if ttl <= 0 {
+			return t.base.RoundTrip(req)
+		}
+		if entry, ok := t.cache.get(key); ok && !t.cache.expired(entry, time.Now()) {
+			return cachedResponse(req, entry), nil
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK || ttl <= 0 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A failure to cache only costs a request next time
+	_ = t.cache.put(key, cacheEntry{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Endpoint: endpoint,
+		StoredAt: time.Now(),
+		Body:     body,
+	})
+
+	return resp, nil
+}
+
+// cachedResponse returns the response of a cache entry. Its Age header is the age of the entry.
+func cachedResponse(req *http.Request, entry cacheEntry) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Age", strconv.Itoa(int(time.Since(entry.StoredAt).Seconds())))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,172 @@
+package hlstats_test
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+func TestParseCacheTTLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			items: nil,
+			want:  map[string]time.Duration{"vaults": 10 * time.Minute, "vaultDetails": 5 * time.Minute, "unknown": hlstats.DefaultCacheTTL},
+		},
+		{
+			name:  "overrides",
+			items: []string{"vaultDetails=10m", " vaults=0s", "userFills=30s"},
+			want:  map[string]time.Duration{"vaults": 0, "vaultDetails": 10 * time.Minute, "userFills": 30 * time.Second, "daily_usd_volume": 3 * time.Hour},
+		},
+		{name: "missing duration", items: []string{"vaults"}, wantErr: true},
+		{name: "invalid duration", items: []string{"vaults=soon"}, wantErr: true},
+		{name: "negative duration", items: []string{"vaults=-1m"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hlstats.ParseCacheTTLs(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCacheTTLs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for endpoint, want := range tt.want {
+				if ttl := got.TTL(endpoint); ttl != want {
+					t.Errorf("TTL(%q) = %v, want %v", endpoint, ttl, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	var requests atomic.Int32
+	server := newTestServer(t, &requests)
+	cache, err := hlstats.NewResponseCache(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewResponseCache() error = %v", err)
+	}
+
+	newClient := func(mode hlstats.CacheMode) *hlstats.Client {
+		return hlstats.NewClient(server.URL, server.URL+"/info",
+			hlstats.WithVaultsURL(server.URL+"/vaults"),
+			hlstats.WithLimiter(nil),
+			hlstats.WithRetryPolicy(hlstats.RetryPolicy{MaxAttempts: 1}),
+			hlstats.WithCache(cache, mode),
+		)
+	}
+	ctx := context.Background()
+
+	// Offline with an empty cache
+	if _, err := newClient(hlstats.CacheOffline).FetchLargestUsers(ctx); !errors.Is(err, hlstats.ErrCacheMiss) {
+		t.Fatalf("offline FetchLargestUsers() error = %v, want ErrCacheMiss", err)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Fatalf("offline client sent %d requests", got)
+	}
+
+	// The first fetch fills the cache and the next ones are served from it
+	wantUsers, wantVaults, wantDetails := fetchAll(t, newClient(hlstats.CacheDefault))
+	if got := requests.Load(); got != 3 {
+		t.Fatalf("sent %d requests, want 3", got)
+	}
+	for _, mode := range []hlstats.CacheMode{hlstats.CacheDefault, hlstats.CacheOffline} {
+		users, vaults, details := fetchAll(t, newClient(mode))
+		if len(users) != len(wantUsers) || len(vaults) != len(wantVaults) || details.Name != wantDetails.Name {
+			t.Errorf("cached responses differ from the fetched ones in mode %d", mode)
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("cached fetches sent %d requests, want none", got-3)
+	}
+
+	// Errors are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := newClient(hlstats.CacheDefault).FetchDailyVolume(ctx, nil, nil); err == nil {
+			t.Fatalf("FetchDailyVolume() error = nil")
+		}
+	}
+	if got := requests.Load(); got != 5 {
+		t.Errorf("failed fetches sent %d requests, want 2", got-3)
+	}
+
+	// Refresh fetches again
+	fetchAll(t, newClient(hlstats.CacheRefresh))
+	if got := requests.Load(); got != 8 {
+		t.Errorf("refresh sent %d requests, want 3", got-5)
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	var endpoints []string
+	for _, s := range stats {
+		if s.Entries != 1 || s.Expired != 0 || s.Bytes == 0 {
+			t.Errorf("Stats() of %s = %+v, want one fresh entry", s.Endpoint, s)
+		}
+		endpoints = append(endpoints, s.Endpoint)
+	}
+	if want := []string{"largest_users_by_usd_volume", "vaultDetails", "vaults"}; !reflect.DeepEqual(endpoints, want) {
+		t.Errorf("Stats() endpoints = %v, want %v", endpoints, want)
+	}
+
+	if removed, err := cache.Clear(true); err != nil || removed != 0 {
+		t.Errorf("Clear(true) = %d, %v, want 0 fresh entries removed", removed, err)
+	}
+	if removed, err := cache.Clear(false); err != nil || removed != 3 {
+		t.Errorf("Clear(false) = %d, %v, want 3", removed, err)
+	}
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	var requests atomic.Int32
+	server := newTestServer(t, &requests)
+	ttls, err := hlstats.ParseCacheTTLs([]string{"vaults=0s", "largest_users_by_usd_volume=1ns"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := hlstats.NewResponseCache(t.TempDir(), ttls)
+	if err != nil {
+		t.Fatalf("NewResponseCache() error = %v", err)
+	}
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithVaultsURL(server.URL+"/vaults"),
+		hlstats.WithLimiter(nil),
+		hlstats.WithCache(cache, hlstats.CacheDefault),
+	)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchAllVault(ctx); err != nil {
+			t.Fatalf("FetchAllVault() error = %v", err)
+		}
+		if _, err := client.FetchLargestUsers(ctx); err != nil {
+			t.Fatalf("FetchLargestUsers() error = %v", err)
+		}
+	}
+
+	// A TTL of 0 disables caching and expired entries are fetched again
+	if got := requests.Load(); got != 4 {
+		t.Errorf("sent %d requests, want 4", got)
+	}
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if len(stats) != 1 || stats[0].Endpoint != "largest_users_by_usd_volume" || stats[0].Expired != 1 {
+		t.Errorf("Stats() = %+v, want one expired leaderboard entry", stats)
+	}
+	if removed, err := cache.Clear(true); err != nil || removed != 1 {
+		t.Errorf("Clear(true) = %d, %v, want 1", removed, err)
+	}
+}
@@ -0,0 +1,363 @@
+package hlstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Client fetches statistics from the Hyperliquid stats, info and vaults endpoints.
+// A Client is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	infoURL    string
+	vaultsURL  string
+	network    Network
+	headers    http.Header
+	httpClient *http.Client
+	transport  *retryTransport
+	progress   ProgressReporter
+}
+
+const (
+	// defaultRequestTimeout bounds every single HTTP attempt.
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// NewClient creates a client for the stats endpoint at baseURL and the info endpoint at infoURL.
+func NewClient(baseURL, infoURL string, opts ...Option) *Client {
+	o := defaultClientOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		httpClient = &copied
+	}
+
+	base := o.transport
+	if base == nil {
+		base = httpClient.Transport
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	transport := &retryTransport{
+		base:    base,
+		policy:  o.retry,
+		timeout: o.timeout,
+		limiter: o.limiter,
+		weights: o.weights,
+	}
+	httpClient.Transport = transport
+	if o.cache != nil {
+		httpClient.Transport = &cacheTransport{base: transport, cache: o.cache, mode: o.cacheMode}
+	}
+	if o.recordDir != "" {
+		httpClient.Transport = &recordTransport{base: httpClient.Transport, dir: o.recordDir}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		infoURL:    strings.TrimRight(infoURL, "/"),
+		vaultsURL:  o.resolvedVaultsURL(),
+		network:    o.network,
+		headers:    o.headers,
+		httpClient: httpClient,
+		transport:  transport,
+		progress:   o.progress,
+	}
+}
+
+// ErrStatsUnavailable is returned by the stats endpoints when the client has no base URL,
+// e.g. on networks without a public stats endpoint.
+var ErrStatsUnavailable = errors.New("stats endpoint is not available for this network")
+
+// ErrVaultsUnavailable is returned by FetchAllVault when the client has no vaults URL,
+// e.g. on networks without a known vault listing.
+var ErrVaultsUnavailable = errors.New("vault listing endpoint is not available for this network")
+
+// BuildURL returns the URL of path on the stats endpoint.
+func (c *Client) BuildURL(path string) string {
+	return fmt.Sprintf("%s/%s", c.baseURL, strings.TrimLeft(path, "/"))
+}
+
+// Network returns the network profile of the client.
+func (c *Client) Network() Network {
+	return c.network
+}
+
+// FetchLargestUsers returns the users with the largest USD volume.
+func (c *Client) FetchLargestUsers(ctx context.Context) (USDVolumeByUsers, error) {
+	if c.baseURL == "" {
+		return nil, ErrStatsUnavailable
+	}
+
+	var result LargestVolumeResponse
+	err := c.fetchData(ctx, c.BuildURL("largest_users_by_usd_volume"), &result)
+	return result.Data, err
+}
+
+// FetchLargestTradeCounts returns the users with the largest trade count.
+func (c *Client) FetchLargestTradeCounts(ctx context.Context) (LargestTradeCounts, error) {
+	if c.baseURL == "" {
+		return nil, ErrStatsUnavailable
+	}
+
+	var result LargestTradeCountResponse
+	err := c.fetchData(ctx, c.BuildURL("largest_users_by_trade_count"), &result)
+	return result.Data, err
+}
+
+// FetchDailyVolumeByUser returns the daily USD volume per user, optionally restricted to a date range and a user.
+func (c *Client) FetchDailyVolumeByUser(ctx context.Context, fromDate, toDate *time.Time, username string) (DailyVolumeByUsers, error) {
+	if c.baseURL == "" {
+		return nil, ErrStatsUnavailable
+	}
+
+	var result DailyVolumeByUserResponse
+
+	// Build endpoint with optional parameters
+	endpoint := "daily_usd_volume_by_user"
+	params := url.Values{}
+
+	if fromDate != nil {
+		params.Add("from_date", fromDate.Format("2006-01-02"))
+	}
+
+	if toDate != nil {
+		params.Add("to_date", toDate.Format("2006-01-02"))
+	}
+
+	if username != "" {
+		params.Add("user", username)
+	}
+
+	if len(params) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, params.Encode())
+	}
+
+	err := c.fetchData(ctx, c.BuildURL(endpoint), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply client-side filtering if needed
+	if fromDate != nil || toDate != nil {
+		result.Data = result.Data.FilterByDateRange(fromDate, toDate)
+	}
+
+	// Apply client-side user filtering if needed (fallback if API doesn't support it)
+	if username != "" {
+		result.Data = result.Data.FilterByUser(username)
+	}
+
+	return result.Data, nil
+}
+
+// FetchDailyVolume returns the total daily USD volume, optionally restricted to a date range.
+func (c *Client) FetchDailyVolume(ctx context.Context, fromDate, toDate *time.Time) (DailyVolumes, error) {
+	if c.baseURL == "" {
+		return nil, ErrStatsUnavailable
+	}
+
+	var result DailyVolumeResponse
+
+	// Build endpoint with optional parameters
+	endpoint := "daily_usd_volume"
+	params := url.Values{}
+
+	if fromDate != nil {
+		params.Add("from_date", fromDate.Format("2006-01-02"))
+	}
+
+	if toDate != nil {
+		params.Add("to_date", toDate.Format("2006-01-02"))
+	}
+
+	if len(params) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, params.Encode())
+	}
+
+	err := c.fetchData(ctx, c.BuildURL(endpoint), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply client-side filtering if needed
+	if fromDate != nil || toDate != nil {
+		result.Data = result.Data.FilterByDateRange(fromDate, toDate)
+	}
+
+	return result.Data, nil
+}
+
+// FetchAllVault returns every vault listed by the vaults endpoint, open and closed.
+func (c *Client) FetchAllVault(ctx context.Context) (Vaults, error) {
+	if c.vaultsURL == "" {
+		return nil, ErrVaultsUnavailable
+	}
+
+	result := Vaults{}
+	err := c.fetchData(ctx, c.vaultsURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].HLP = c.network.IsHLPLeader(result[i].Data.Leader) ||
+			c.network.IsHLPParent(result[i].Data.Relationship.ParentAddress)
+	}
+
+	return result, nil
+}
+
+// FetchVaultVolume returns the trading volume of a single vault.
+// Use FetchVaultDetails for the account value and PnL history.
+func (c *Client) FetchVaultVolume(ctx context.Context, vaultAddress string) (VaultVolume, error) {
+	var result VaultVolumeResponse
+
+	payload := VaultVolumeRequest{
+		Type:    "vaultDetails",
+		Address: vaultAddress,
+		User:    "",
+	}
+
+	err := c.PostRequest(ctx, c.infoURL, payload, &result)
+	if err != nil {
+		return VaultVolume{}, errors.Wrapf(err, "failed to fetch vault volume for address %s", vaultAddress)
+	}
+
+	return result.Portfolio.Volume(), nil
+}
+
+// FetchAllVaultVolumes is FetchAllVaultVolumesConcurrent with a single worker.
+func (c *Client) FetchAllVaultVolumes(ctx context.Context, hlpOnly bool, page Page, filters ...VaultPredicate) (VaultVolumesInfo, error) {
+	return c.FetchAllVaultVolumesConcurrent(ctx, hlpOnly, page, 1, filters...)
+}
+
+// FetchAllVaultVolumesConcurrent fetches the volumes of the vaults matching filters (by default,
+// open vaults with some TVL) using a pool of workers. The page selects the vaults to fetch after
+// filtering, in listing order: HLP vaults first, then by TVL descending.
+// If ctx is cancelled, in-flight fetches are aborted and the volumes collected so far are
+// returned together with the context error. If some vaults fail, the successful volumes are
+// returned together with a *PartialFetchError describing each failed vault.
+func (c *Client) FetchAllVaultVolumesConcurrent(ctx context.Context, hlpOnly bool, page Page, workers int, filters ...VaultPredicate) (VaultVolumesInfo, error) {
+	vaults, err := c.selectVaults(ctx, hlpOnly, page, filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(vaults) == 0 {
+		return VaultVolumesInfo{}, nil
+	}
+
+	result, err := fetchVaultsConcurrent(ctx, c, vaults, workers, "vault volume",
+		func(ctx context.Context, vault Vault) (VaultVolumeInfo, error) {
+			details, err := c.FetchVaultDetails(ctx, vault.Data.Address)
+			if err != nil {
+				return VaultVolumeInfo{}, err
+			}
+
+			parent := details.Relationship.ParentAddress
+			return VaultVolumeInfo{
+				Address: vault.Data.Address,
+				Name:    vault.Data.Name,
+				Parent:  parent,
+				Volume:  details.Portfolio.Volume(),
+				TVL:     vault.Data.TVL,
+				IsHLP:   vault.IsHLP() || c.network.IsHLPParent(parent),
+			}, nil
+		})
+
+	return result, err
+}
+
+// FetchData sends a GET request to url and decodes the JSON response into result.
+func (c *Client) FetchData(ctx context.Context, url string, result interface{}) error {
+	return c.fetchData(ctx, url, result)
+}
+
+// PostRequest sends payload as JSON to url and decodes the JSON response into result.
+func (c *Client) PostRequest(ctx context.Context, url string, payload interface{}, result interface{}) error {
+	// Marshal the payload to JSON
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal request payload")
+	}
+
+	// Create the POST request
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request")
+	}
+
+	// Set headers
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Make the request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make POST request to %s", url)
+	}
+	defer resp.Body.Close()
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	// Read and parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response")
+	}
+
+	return decode(url, body, result)
+}
+
+func (c *Client) fetchData(ctx context.Context, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request")
+	}
+	c.setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch data")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newStatusError(resp, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response")
+	}
+
+	return decode(url, body, result)
+}
+
+// setHeaders applies the default and user-configured headers to req.
+func (c *Client) setHeaders(req *http.Request) {
+	req.Header.Set("Accept", "application/json")
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+}
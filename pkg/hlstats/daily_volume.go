@@ -1,14 +1,12 @@
-package api
+package hlstats
 
 import (
 	"encoding/json"
-	"fmt"
 	"sort"
 	"time"
-
-	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
 )
 
+// DailyVolume is the total USD volume traded on a day.
 type DailyVolume struct {
 	Time   time.Time `json:"time"`
 	Volume float64   `json:"daily_usd_volume"`
@@ -33,6 +31,7 @@ func (item *DailyVolume) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// DailyVolumes is a list of daily volumes.
 type DailyVolumes []DailyVolume
 
 // FilterByDateRange filters the data to include only entries within the specified date range
@@ -75,32 +74,7 @@ func (data DailyVolumes) SortByTime(descending bool) DailyVolumes {
 	return sorted
 }
 
-// FormatString formats the daily volume data as a table string
-func (data DailyVolumes) FormatString(count int) string {
-	ret := common.NewTableFormatter().WithHeader("Daily Volume")
-	ret = ret.WithHeader("Date", "Volume ($B)")
-
-	if count > len(data) {
-		count = len(data)
-	}
-	if count == 0 {
-		count = len(data)
-	}
-
-	sum := 0.0
-
-	for i := 0; i < count; i++ {
-		sum += data[i].Volume
-		ret = ret.WithRow(
-			data[i].Time.Format("2006-01-02"),
-			fmt.Sprintf("%.4f", data[i].Volume/1000000000),
-		)
-	}
-	ret = ret.WithFooter("SUM", fmt.Sprintf("%.4f", sum/1000000000))
-
-	return ret.String()
-}
-
+// DailyVolumeResponse is the response of the daily_usd_volume endpoint.
 type DailyVolumeResponse struct {
 	Data DailyVolumes `json:"chart_data"`
 }
@@ -1,15 +1,13 @@
-package api
+package hlstats
 
 import (
 	"encoding/json"
-	"fmt"
 	"sort"
 	"strings"
 	"time"
-
-	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
 )
 
+// DailyVolumeByUser is the USD volume traded by a user on a day.
 type DailyVolumeByUser struct {
 	Time   time.Time `json:"time"`
 	User   string    `json:"user"`
@@ -37,8 +35,10 @@ func (item *DailyVolumeByUser) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// DailyVolumeByUsers is a list of daily volumes per user.
 type DailyVolumeByUsers []DailyVolumeByUser
 
+// FilterByDateRange filters the data to include only entries within the specified date range
 func (data DailyVolumeByUsers) FilterByDateRange(fromDate, toDate *time.Time) DailyVolumeByUsers {
 	if fromDate == nil && toDate == nil {
 		return data
@@ -104,28 +104,7 @@ func (data DailyVolumeByUsers) SortByTime(descending bool) DailyVolumeByUsers {
 	return sorted
 }
 
-func (data DailyVolumeByUsers) FormatString(count int) string {
-	ret := common.NewTableFormatter().WithHeader("Daily Volume By User")
-	ret = ret.WithHeader("Date", "User", "Volume ($M USD)")
-
-	if count > len(data) {
-		count = len(data)
-	}
-	if count == 0 {
-		count = len(data)
-	}
-
-	for i := 0; i < count; i++ {
-		ret = ret.WithRow(
-			data[i].Time.Format("2006-01-02"),
-			data[i].User,
-			fmt.Sprintf("%.4f", data[i].Volume/1000000),
-		)
-	}
-
-	return ret.String()
-}
-
+// DailyVolumeByUserResponse is the response of the daily_usd_volume_by_user endpoint.
 type DailyVolumeByUserResponse struct {
 	Data DailyVolumeByUsers `json:"chart_data"`
 }
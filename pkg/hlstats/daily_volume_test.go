@@ -0,0 +1,159 @@
+package hlstats_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// day returns midnight UTC of the given day of October 2026.
+func day(d int) time.Time {
+	return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDailyVolumeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.DailyVolume
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `{"time":"2026-10-01T00:00:00","daily_usd_volume":1234.5}`,
+			want: hlstats.DailyVolume{Time: day(1), Volume: 1234.5},
+		},
+		{name: "date only", data: `{"time":"2026-10-01","daily_usd_volume":1}`, wantErr: true},
+		{name: "missing time", data: `{"daily_usd_volume":1}`, wantErr: true},
+		{name: "string volume", data: `{"time":"2026-10-01T00:00:00","daily_usd_volume":"1"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.DailyVolume
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyVolumeByUserUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.DailyVolumeByUser
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `{"time":"2026-10-02T00:00:00","user":"0xu1","daily_usd_volume":250.5}`,
+			want: hlstats.DailyVolumeByUser{Time: day(2), User: "0xu1", Volume: 250.5},
+		},
+		{name: "invalid time", data: `{"time":"02/10/2026","user":"0xu1"}`, wantErr: true},
+		{name: "not an object", data: `[]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.DailyVolumeByUser
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyVolumesFilterAndSort(t *testing.T) {
+	data := hlstats.DailyVolumes{
+		{Time: day(2), Volume: 2},
+		{Time: day(4).Add(12 * time.Hour), Volume: 4},
+		{Time: day(1), Volume: 1},
+		{Time: day(3), Volume: 3},
+	}
+	from, to := day(2), day(4)
+
+	volumes := func(data hlstats.DailyVolumes) []float64 {
+		var result []float64
+		for _, item := range data {
+			result = append(result, item.Volume)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		got  hlstats.DailyVolumes
+		want []float64
+	}{
+		{name: "no range", got: data.FilterByDateRange(nil, nil), want: []float64{2, 4, 1, 3}},
+		{name: "from", got: data.FilterByDateRange(&from, nil), want: []float64{2, 4, 3}},
+		{name: "to", got: data.FilterByDateRange(nil, &from), want: []float64{2, 1}},
+		{name: "range includes the whole last day", got: data.FilterByDateRange(&from, &to), want: []float64{2, 4, 3}},
+		{name: "descending", got: data.SortByTime(true), want: []float64{4, 3, 2, 1}},
+		{name: "ascending", got: data.SortByTime(false), want: []float64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(volumes(tt.got), tt.want) {
+				t.Errorf("got %v, want %v", volumes(tt.got), tt.want)
+			}
+		})
+	}
+
+	if data[0].Volume != 2 {
+		t.Errorf("SortByTime() modified its input")
+	}
+}
+
+func TestDailyVolumeByUsersFilterAndSort(t *testing.T) {
+	data := hlstats.DailyVolumeByUsers{
+		{Time: day(1), User: "0xu1", Volume: 100},
+		{Time: day(2), User: "0xU2", Volume: 50},
+		{Time: day(1), User: "0xu2", Volume: 250},
+		{Time: day(2), User: "0xu1", Volume: 200},
+		{Time: day(3), User: "0xu1", Volume: 300},
+	}
+	from, to := day(2), day(2)
+
+	entries := func(data hlstats.DailyVolumeByUsers) []string {
+		var result []string
+		for _, item := range data {
+			result = append(result, item.Time.Format("02")+"/"+item.User)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		got  hlstats.DailyVolumeByUsers
+		want []string
+	}{
+		{name: "user", got: data.FilterByUser("0xu2"), want: []string{"02/0xU2", "01/0xu2"}},
+		{name: "no user", got: data.FilterByUser(""), want: entries(data)},
+		{name: "unknown user", got: data.FilterByUser("0xu9"), want: nil},
+		{name: "range", got: data.FilterByDateRange(&from, &to), want: []string{"02/0xU2", "02/0xu1"}},
+		{name: "descending by day then volume", got: data.SortByTime(true), want: []string{"03/0xu1", "02/0xu1", "02/0xU2", "01/0xu2", "01/0xu1"}},
+		{name: "ascending by day then volume", got: data.SortByTime(false), want: []string{"01/0xu2", "01/0xu1", "02/0xu1", "02/0xU2", "03/0xu1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(entries(tt.got), tt.want) {
+				t.Errorf("got %v, want %v", entries(tt.got), tt.want)
+			}
+		})
+	}
+}
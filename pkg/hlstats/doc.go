@@ -0,0 +1,8 @@
+// Package hlstats is a Go client for Hyperliquid statistics: leaderboards and daily volumes from the
+// stats endpoint, the vaults listing from stats-data and per-vault volumes from the info endpoint.
+//
+// The model types carry raw values only; formatting is left to the caller.
+//
+//	client := hlstats.NewClient(hlstats.Mainnet().BaseURL, hlstats.Mainnet().InfoURL)
+//	vaults, err := client.FetchAllVault(ctx)
+package hlstats
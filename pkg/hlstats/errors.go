@@ -0,0 +1,156 @@
+package hlstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxSnippetLen bounds the response body and payload excerpts kept in errors.
+const maxSnippetLen = 256
+
+// ErrRateLimited matches every rate limiting error (HTTP 429) with errors.Is.
+var ErrRateLimited = errors.New("rate limited")
+
+// StatusError is returned when the API responds with a non-200 HTTP status code.
+type StatusError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Body is the beginning of the response body.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s %s: api returned status %d", e.Method, e.URL, e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s %s: api returned status %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// Is reports HTTP 429 responses as ErrRateLimited.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// RateLimitedError is returned when the API rejects a request with HTTP 429.
+// It matches ErrRateLimited with errors.Is.
+type RateLimitedError struct {
+	// RetryAfter is the delay requested by the API, if any.
+	RetryAfter time.Duration
+	// Status is the HTTP 429 response.
+	Status *StatusError
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v (retry after %v)", e.Status, e.RetryAfter)
+	}
+
+	return e.Status.Error()
+}
+
+func (e *RateLimitedError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Status
+}
+
+// DecodeError is returned when a response body cannot be decoded.
+type DecodeError struct {
+	URL string
+	// Path is the JSON path of the offending value, when known.
+	Path string
+	// Payload is an excerpt of the response body around the offending value.
+	Payload string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("failed to decode response from %s: %v", e.URL, e.Err)
+	}
+
+	return fmt.Sprintf("failed to decode response from %s at %s: %v", e.URL, e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newStatusError builds the error for a non-200 response. HTTP 429 responses are wrapped in a RateLimitedError.
+func newStatusError(resp *http.Response, body []byte) error {
+	statusErr := &StatusError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL.String(),
+		Body:       snippet(body, 0),
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return statusErr
+	}
+
+	retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"))
+	return &RateLimitedError{RetryAfter: retryAfter, Status: statusErr}
+}
+
+// decode unmarshals body into result, returning a *DecodeError on failure.
+func decode(url string, body []byte, result interface{}) error {
+	err := json.Unmarshal(body, result)
+	if err == nil {
+		return nil
+	}
+
+	decodeErr := &DecodeError{URL: url, Err: err, Payload: snippet(body, 0)}
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		decodeErr.Path = typeErr.Field
+		decodeErr.Payload = snippet(body, typeErr.Offset)
+	case errors.As(err, &syntaxErr):
+		decodeErr.Payload = snippet(body, syntaxErr.Offset)
+	}
+
+	return decodeErr
+}
+
+// snippet returns at most maxSnippetLen bytes of data, centred on offset.
+func snippet(data []byte, offset int64) string {
+	start := int(offset) - maxSnippetLen/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxSnippetLen
+	if end > len(data) {
+		end = len(data)
+	}
+	if start > end {
+		start = end
+	}
+
+	return string(data[start:end])
+}
+
+// statusCodeOf returns the HTTP status code carried by err, or 0 if there is none.
+func statusCodeOf(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+
+	return 0
+}
+
+// IsRateLimited reports whether err was caused by the API rate limiting the client (HTTP 429).
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
@@ -0,0 +1,103 @@
+package hlstats_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// newExampleServer serves canned responses for the stats, vaults and info endpoints.
+func newExampleServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/largest_users_by_usd_volume", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"table_data":[{"name":"0xabc","value":1500000.5},{"name":"0xdef","value":250000}]}`)
+	})
+	mux.HandleFunc("/vaults", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[
+			{"summary":{"name":"Hyperliquidity Provider (HLP)","vaultAddress":"0x1","leader":"0xdfc24b077bc1425ad1dea75bcb6f8158e10df303","tvl":"350000000.0","isClosed":false}},
+			{"summary":{"name":"Alpha","vaultAddress":"0x2","leader":"0x3","tvl":"1200.5","isClosed":false}},
+			{"summary":{"name":"Retired","vaultAddress":"0x4","leader":"0x5","tvl":"0.0","isClosed":true}}
+		]`)
+	})
+	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"portfolio":[["day",{"vlm":"100.0"}],["week",{"vlm":"700.0"}],["month",{"vlm":"3000.0"}],["allTime",{"vlm":"50000.0"}]]}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func ExampleClient_FetchLargestUsers() {
+	server := newExampleServer()
+	defer server.Close()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info", hlstats.WithLimiter(nil))
+	users, err := client.FetchLargestUsers(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, user := range users {
+		fmt.Printf("%s %.2f\n", user.Name, user.Value)
+	}
+	// Output:
+	// 0xabc 1500000.50
+	// 0xdef 250000.00
+}
+
+func ExampleClient_FetchAllVault() {
+	server := newExampleServer()
+	defer server.Close()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithVaultsURL(server.URL+"/vaults"),
+		hlstats.WithLimiter(nil),
+	)
+	vaults, err := client.FetchAllVault(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, vault := range vaults.FilterOpenVaults().SortWithHLPPriority(false) {
+		fmt.Printf("%s hlp=%v tvl=%.1f\n", vault.Data.Name, vault.IsHLP(), vault.Data.TVL)
+	}
+	// Output:
+	// Hyperliquidity Provider (HLP) hlp=true tvl=350000000.0
+	// Alpha hlp=false tvl=1200.5
+}
+
+func ExampleClient_FetchVaultVolume() {
+	server := newExampleServer()
+	defer server.Close()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithTimeout(5*time.Second),
+		hlstats.WithLimiter(nil),
+	)
+	volume, err := client.FetchVaultVolume(context.Background(), "0x1")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("day=%.0f week=%.0f month=%.0f allTime=%.0f\n", volume.Day, volume.Week, volume.Month, volume.AllTime)
+	// Output:
+	// day=100 week=700 month=3000 allTime=50000
+}
+
+func ExampleLookupNetwork() {
+	network, err := hlstats.LookupNetwork("testnet", nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(network.Name, network.InfoURL)
+	// Output:
+	// testnet https://api.hyperliquid-testnet.xyz/info
+}
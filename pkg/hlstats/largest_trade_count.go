@@ -1,12 +1,10 @@
-package api
+package hlstats
 
 import (
 	"encoding/json"
-	"fmt"
-
-	"github.com/LampardNguyen234/hyperliquid-stats/pkg/common"
 )
 
+// LargestTradeCount is the number of trades of a user.
 type LargestTradeCount struct {
 	Name  string `json:"name"`
 	Value uint64 `json:"value"`
@@ -27,23 +25,10 @@ func (item *LargestTradeCount) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// LargestTradeCounts is a leaderboard of users by trade count.
 type LargestTradeCounts []LargestTradeCount
 
-func (data LargestTradeCounts) FormatString(count int) string {
-	ret := common.NewTableFormatter().WithHeader("Largest Trade Count By User")
-	ret = ret.WithHeader("User", "Trade Count")
-
-	if count > len(data) {
-		count = len(data)
-	}
-
-	for i := 0; i < count; i++ {
-		ret = ret.WithRow(data[i].Name, fmt.Sprintf("%d", data[i].Value))
-	}
-
-	return ret.String()
-}
-
+// LargestTradeCountResponse is the response of the largest_users_by_trade_count endpoint.
 type LargestTradeCountResponse struct {
 	Data LargestTradeCounts `json:"chart_data"`
 }
@@ -0,0 +1,35 @@
+package hlstats_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestLargestTradeCountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.LargestTradeCount
+		wantErr bool
+	}{
+		{name: "integer", data: `{"name":"0xu1","value":30}`, want: hlstats.LargestTradeCount{Name: "0xu1", Value: 30}},
+		{name: "float is truncated", data: `{"name":"0xu2","value":1.2e3}`, want: hlstats.LargestTradeCount{Name: "0xu2", Value: 1200}},
+		{name: "missing value", data: `{"name":"0xu3"}`, want: hlstats.LargestTradeCount{Name: "0xu3"}},
+		{name: "string value", data: `{"name":"0xu4","value":"30"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.LargestTradeCount
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package hlstats
+
+// USDVolumeByUser is the USD volume traded by a user.
+type USDVolumeByUser struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// USDVolumeByUsers is a leaderboard of users by USD volume.
+type USDVolumeByUsers []USDVolumeByUser
+
+// LargestVolumeResponse is the response of the largest_users_by_usd_volume endpoint.
+type LargestVolumeResponse struct {
+	Data USDVolumeByUsers `json:"table_data"`
+}
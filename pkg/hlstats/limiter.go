@@ -0,0 +1,226 @@
+package hlstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Limiter throttles outgoing requests.
+type Limiter interface {
+	// Wait blocks until a request of the given weight may be sent, or returns the context error if ctx is done first.
+	Wait(ctx context.Context, weight int) error
+}
+
+// LimitWindow allows at most Limit units of request weight within any sliding Interval.
+type LimitWindow struct {
+	Limit    int
+	Interval time.Duration
+}
+
+// ParseLimitWindow parses a window given as "<limit>/<interval>", e.g. "1200/1m" or "3/100ms".
+func ParseLimitWindow(s string) (LimitWindow, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	if len(parts) != 2 {
+		return LimitWindow{}, errors.Errorf("invalid rate limit '%s'. Expected format: <limit>/<interval>, e.g. 1200/1m", s)
+	}
+
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil || limit <= 0 {
+		return LimitWindow{}, errors.Errorf("invalid limit in rate limit '%s'", s)
+	}
+
+	interval, err := time.ParseDuration(parts[1])
+	if err != nil || interval <= 0 {
+		return LimitWindow{}, errors.Errorf("invalid interval in rate limit '%s'", s)
+	}
+
+	return LimitWindow{Limit: limit, Interval: interval}, nil
+}
+
+func (w LimitWindow) String() string {
+	return fmt.Sprintf("%d/%v", w.Limit, w.Interval)
+}
+
+// DefaultLimitWindows returns the windows used by NewClient: 300 units per minute and 3 units per 100ms.
+func DefaultLimitWindows() []LimitWindow {
+	return []LimitWindow{
+		{Limit: 300, Interval: time.Minute},
+		{Limit: 3, Interval: 100 * time.Millisecond},
+	}
+}
+
+// GetRequestWeightKey is the RequestWeights key used for GET requests.
+const GetRequestWeightKey = "get"
+
+// RequestWeights maps a request type to the weight it consumes from the limiter. POST requests to the
+// info endpoint are keyed by their "type" field (e.g. "vaultDetails"); GET requests use GetRequestWeightKey.
+// Request types without an entry weigh 1.
+type RequestWeights map[string]int
+
+// ParseRequestWeights parses weights given as "<type>=<weight>", e.g. "vaultDetails=20".
+func ParseRequestWeights(items []string) (RequestWeights, error) {
+	weights := RequestWeights{}
+	for _, item := range items {
+		parts := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid request weight '%s'. Expected format: <type>=<weight>", item)
+		}
+
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, errors.Errorf("invalid weight in '%s'", item)
+		}
+		weights[parts[0]] = weight
+	}
+
+	return weights, nil
+}
+
+// Weight returns the weight of the given request type.
+func (w RequestWeights) Weight(requestType string) int {
+	if weight, ok := w[requestType]; ok {
+		return weight
+	}
+
+	return 1
+}
+
+// requestType returns the RequestWeights key of req.
+func requestType(req *http.Request) string {
+	if req.Method == http.MethodGet || req.GetBody == nil {
+		return GetRequestWeightKey
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	var payload struct {
+		Type string `json:"type"`
+	}
+	data, _ := io.ReadAll(body)
+	_ = json.NewDecoder(bytes.NewReader(data)).Decode(&payload)
+
+	return payload.Type
+}
+
+// limitEvent records a request admitted by a limiter.
+type limitEvent struct {
+	At     int64 `json:"t"`
+	Weight int   `json:"w"`
+}
+
+// pruneEvents drops the events that are older than every window.
+func pruneEvents(events []limitEvent, now time.Time, windows []LimitWindow) []limitEvent {
+	var maxInterval time.Duration
+	for _, w := range windows {
+		if w.Interval > maxInterval {
+			maxInterval = w.Interval
+		}
+	}
+
+	cutoff := now.Add(-maxInterval).UnixNano()
+	i := 0
+	for i < len(events) && events[i].At <= cutoff {
+		i++
+	}
+
+	return events[i:]
+}
+
+// limitDelay returns how long to wait until weight fits into every window, or 0 if it fits now.
+// events must be in chronological order.
+func limitDelay(events []limitEvent, now time.Time, weight int, windows []LimitWindow) time.Duration {
+	var wait time.Duration
+	for _, w := range windows {
+		// A request heavier than the window would never fit; let it through once the window is empty.
+		need := weight
+		if need > w.Limit {
+			need = w.Limit
+		}
+
+		start := now.Add(-w.Interval).UnixNano()
+		used := 0
+		for _, e := range events {
+			if e.At > start {
+				used += e.Weight
+			}
+		}
+
+		excess := used + need - w.Limit
+		if excess <= 0 {
+			continue
+		}
+
+		for _, e := range events {
+			if e.At <= start {
+				continue
+			}
+			excess -= e.Weight
+			if excess <= 0 {
+				if d := time.Unix(0, e.At).Add(w.Interval).Sub(now); d > wait {
+					wait = d
+				}
+				break
+			}
+		}
+	}
+
+	return wait
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// windowLimiter is an in-process sliding window limiter.
+type windowLimiter struct {
+	mu      sync.Mutex
+	windows []LimitWindow
+	events  []limitEvent
+}
+
+// NewLimiter returns an in-process limiter enforcing all given windows.
+func NewLimiter(windows ...LimitWindow) Limiter {
+	return &windowLimiter{windows: windows}
+}
+
+func (l *windowLimiter) Wait(ctx context.Context, weight int) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.events = pruneEvents(l.events, now, l.windows)
+		d := limitDelay(l.events, now, weight, l.windows)
+		if d <= 0 {
+			l.events = append(l.events, limitEvent{At: now.UnixNano(), Weight: weight})
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package hlstats
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fileLimiter is a sliding window limiter whose state lives in a file, so that several processes
+// on the same host share one request budget. Access to the file is serialised with an advisory lock.
+type fileLimiter struct {
+	path    string
+	windows []LimitWindow
+}
+
+// NewFileLimiter returns a limiter enforcing all given windows across every process that uses the same path.
+func NewFileLimiter(path string, windows ...LimitWindow) (Limiter, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open rate limit file %s", path)
+	}
+	f.Close()
+
+	return &fileLimiter{path: path, windows: windows}, nil
+}
+
+func (l *fileLimiter) Wait(ctx context.Context, weight int) error {
+	for {
+		d, err := l.tryReserve(weight)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return nil
+		}
+
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// tryReserve records the request if it fits into every window, otherwise it returns how long to wait.
+func (l *fileLimiter) tryReserve(weight int) (time.Duration, error) {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to open rate limit file %s", l.path)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return 0, errors.Wrapf(err, "failed to lock rate limit file %s", l.path)
+	}
+	defer unlockFile(f)
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read rate limit file %s", l.path)
+	}
+
+	var events []limitEvent
+	if len(data) > 0 {
+		// A corrupted state only loses the recent history; start over rather than failing every request.
+		_ = json.Unmarshal(data, &events)
+	}
+
+	now := time.Now()
+	events = pruneEvents(events, now, l.windows)
+	if d := limitDelay(events, now, weight, l.windows); d > 0 {
+		return d, nil
+	}
+	events = append(events, limitEvent{At: now.UnixNano(), Weight: weight})
+
+	data, err = json.Marshal(events)
+	if err != nil {
+		return 0, err
+	}
+	if err := f.Truncate(0); err != nil {
+		return 0, errors.Wrapf(err, "failed to write rate limit file %s", l.path)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return 0, errors.Wrapf(err, "failed to write rate limit file %s", l.path)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,223 @@
+package hlstats_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+func TestParseLimitWindow(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    hlstats.LimitWindow
+		wantErr bool
+	}{
+		{input: "1200/1m", want: hlstats.LimitWindow{Limit: 1200, Interval: time.Minute}},
+		{input: " 3/100ms ", want: hlstats.LimitWindow{Limit: 3, Interval: 100 * time.Millisecond}},
+		{input: "1200", wantErr: true},
+		{input: "0/1m", wantErr: true},
+		{input: "10/0s", wantErr: true},
+		{input: "10/soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := hlstats.ParseLimitWindow(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimitWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLimitWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequestWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		want    map[string]int
+		wantErr bool
+	}{
+		{name: "defaults", want: map[string]int{"vaultDetails": 1, hlstats.GetRequestWeightKey: 1}},
+		{
+			name:  "overrides",
+			items: []string{"vaultDetails=20", " get=0"},
+			want:  map[string]int{"vaultDetails": 20, hlstats.GetRequestWeightKey: 0, "userFills": 1},
+		},
+		{name: "missing weight", items: []string{"vaultDetails"}, wantErr: true},
+		{name: "negative weight", items: []string{"vaultDetails=-1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hlstats.ParseRequestWeights(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRequestWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for requestType, want := range tt.want {
+				if weight := got.Weight(requestType); weight != want {
+					t.Errorf("Weight(%q) = %d, want %d", requestType, weight, want)
+				}
+			}
+		})
+	}
+}
+
+// limiterKinds build the in-process and the file-backed limiter for the same windows.
+var limiterKinds = []struct {
+	name string
+	new  func(t *testing.T, windows ...hlstats.LimitWindow) hlstats.Limiter
+}{
+	{
+		name: "memory",
+		new: func(t *testing.T, windows ...hlstats.LimitWindow) hlstats.Limiter {
+			return hlstats.NewLimiter(windows...)
+		},
+	},
+	{
+		name: "file",
+		new: func(t *testing.T, windows ...hlstats.LimitWindow) hlstats.Limiter {
+			limiter, err := hlstats.NewFileLimiter(filepath.Join(t.TempDir(), "limit"), windows...)
+			if err != nil {
+				t.Fatalf("NewFileLimiter() error = %v", err)
+			}
+			return limiter
+		},
+	},
+}
+
+// admitted reports whether limiter admits a request of the given weight without waiting.
+func admitted(t *testing.T, limiter hlstats.Limiter, weight int) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx, weight)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait(%d) error = %v", weight, err)
+	}
+	return err == nil
+}
+
+func TestLimiterWeightedWindow(t *testing.T) {
+	// The windows are a minute long, so nothing expires during a test.
+	five := hlstats.LimitWindow{Limit: 5, Interval: time.Minute}
+
+	tests := []struct {
+		name    string
+		windows []hlstats.LimitWindow
+		prior   []int
+		weight  int
+		want    bool
+	}{
+		{name: "fits", windows: []hlstats.LimitWindow{five}, prior: []int{2, 2}, weight: 1, want: true},
+		{name: "exceeds", windows: []hlstats.LimitWindow{five}, prior: []int{2, 2}, weight: 2},
+		{name: "full", windows: []hlstats.LimitWindow{five}, prior: []int{5}, weight: 1},
+		{name: "weightless", windows: []hlstats.LimitWindow{five}, prior: []int{5}, weight: 0, want: true},
+		{name: "heavier than the window when empty", windows: []hlstats.LimitWindow{five}, weight: 8, want: true},
+		{name: "heavier than the window when used", windows: []hlstats.LimitWindow{five}, prior: []int{1}, weight: 8},
+		{
+			name:    "tightest window wins",
+			windows: []hlstats.LimitWindow{{Limit: 100, Interval: time.Minute}, {Limit: 3, Interval: time.Minute}},
+			prior:   []int{1, 2},
+			weight:  1,
+		},
+	}
+
+	for _, kind := range limiterKinds {
+		for _, tt := range tests {
+			t.Run(kind.name+"/"+tt.name, func(t *testing.T) {
+				limiter := kind.new(t, tt.windows...)
+				for _, weight := range tt.prior {
+					if !admitted(t, limiter, weight) {
+						t.Fatalf("prior request of weight %d was not admitted", weight)
+					}
+				}
+				if got := admitted(t, limiter, tt.weight); got != tt.want {
+					t.Errorf("request of weight %d admitted = %v, want %v", tt.weight, got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestLimiterSlidingWindow(t *testing.T) {
+	interval := 100 * time.Millisecond
+
+	for _, kind := range limiterKinds {
+		t.Run(kind.name, func(t *testing.T) {
+			limiter := kind.new(t, hlstats.LimitWindow{Limit: 2, Interval: interval})
+			ctx := context.Background()
+
+			start := time.Now()
+			if err := limiter.Wait(ctx, 2); err != nil {
+				t.Fatalf("Wait(2) error = %v", err)
+			}
+			// The window is full until the first request leaves it.
+			if err := limiter.Wait(ctx, 1); err != nil {
+				t.Fatalf("Wait(1) error = %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < interval {
+				t.Errorf("second request admitted after %v, want at least %v", elapsed, interval)
+			}
+		})
+	}
+}
+
+func TestFileLimiterSharesBudget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limit")
+	window := hlstats.LimitWindow{Limit: 5, Interval: time.Minute}
+
+	// Every goroutine opens its own limiter, as separate processes would; only the locked file is
+	// shared, so exactly Limit requests get through.
+	var admittedCount atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter, err := hlstats.NewFileLimiter(path, window)
+			if err != nil {
+				t.Errorf("NewFileLimiter() error = %v", err)
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			if limiter.Wait(ctx, 1) == nil {
+				admittedCount.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := admittedCount.Load(); got != int32(window.Limit) {
+		t.Errorf("admitted %d requests, want %d", got, window.Limit)
+	}
+}
+
+func TestFileLimiterRecoversFromCorruptedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limit")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := hlstats.NewFileLimiter(path, hlstats.LimitWindow{Limit: 1, Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("NewFileLimiter() error = %v", err)
+	}
+	if !admitted(t, limiter, 1) {
+		t.Fatal("first request after a corrupted state was not admitted")
+	}
+	if admitted(t, limiter, 1) {
+		t.Error("second request was admitted beyond the limit")
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package hlstats
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func lockFile(f *os.File) error {
+	return errors.New("shared rate limit files are not supported on this platform")
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package hlstats
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Network is a coherent set of endpoints and well-known addresses of a Hyperliquid deployment.
+type Network struct {
+	Name string
+	// BaseURL serves the stats endpoints (largest users, daily volumes). It may be empty if the
+	// network has no stats endpoint.
+	BaseURL string
+	// InfoURL is the info endpoint used for vaultDetails requests.
+	InfoURL string
+	// VaultsURL is the stats-data endpoint listing all vaults. It may be empty if the network has
+	// no known vault listing.
+	VaultsURL string
+	// HLPLeader is the leader address of the HLP strategy vaults.
+	HLPLeader string
+	// HLPParent is the address of the HLP parent vault.
+	HLPParent string
+}
+
+const (
+	mainnetHLPAddress = "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303"
+	testnetHLPAddress = "0xa15099a30bbf2e68942d6f4c43d70d04faeab0a0"
+)
+
+// Mainnet returns the Hyperliquid mainnet profile.
+func Mainnet() Network {
+	return Network{
+		Name:      "mainnet",
+		BaseURL:   "https://d2v1fiwobg9w6.cloudfront.net",
+		InfoURL:   "https://api.hyperliquid.xyz/info",
+		VaultsURL: DefaultVaultsURL,
+		HLPLeader: mainnetHLPAddress,
+		HLPParent: mainnetHLPAddress,
+	}
+}
+
+// Testnet returns the Hyperliquid testnet profile. Testnet has neither a public stats endpoint nor a
+// known vault listing, so BaseURL and VaultsURL are empty.
+func Testnet() Network {
+	return Network{
+		Name:      "testnet",
+		InfoURL:   "https://api.hyperliquid-testnet.xyz/info",
+		HLPLeader: testnetHLPAddress,
+		HLPParent: testnetHLPAddress,
+	}
+}
+
+// Networks returns the built-in profiles by name.
+func Networks() map[string]Network {
+	return map[string]Network{
+		"mainnet": Mainnet(),
+		"testnet": Testnet(),
+	}
+}
+
+// LookupNetwork returns the profile with the given name from custom, falling back to the built-in profiles.
+// Fields left empty in a custom profile that shares its name with a built-in one are inherited from it.
+func LookupNetwork(name string, custom map[string]Network) (Network, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = "mainnet"
+	}
+
+	builtin, hasBuiltin := Networks()[name]
+	n, hasCustom := custom[name]
+	switch {
+	case hasCustom && hasBuiltin:
+		n = n.inherit(builtin)
+	case hasBuiltin:
+		n = builtin
+	case !hasCustom:
+		names := make([]string, 0)
+		for k := range Networks() {
+			names = append(names, k)
+		}
+		for k := range custom {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return Network{}, errors.Errorf("unknown network '%s'. Valid options: %s", name, strings.Join(names, ", "))
+	}
+	n.Name = name
+
+	return n, nil
+}
+
+// inherit fills the empty fields of n from base.
+func (n Network) inherit(base Network) Network {
+	if n.BaseURL == "" {
+		n.BaseURL = base.BaseURL
+	}
+	if n.InfoURL == "" {
+		n.InfoURL = base.InfoURL
+	}
+	if n.VaultsURL == "" {
+		n.VaultsURL = base.VaultsURL
+	}
+	if n.HLPLeader == "" {
+		n.HLPLeader = base.HLPLeader
+	}
+	if n.HLPParent == "" {
+		n.HLPParent = base.HLPParent
+	}
+
+	return n
+}
+
+// IsHLPLeader reports whether address is the HLP leader of the network.
+func (n Network) IsHLPLeader(address string) bool {
+	return n.HLPLeader != "" && strings.EqualFold(n.HLPLeader, address)
+}
+
+// IsHLPParent reports whether address is the HLP parent vault of the network.
+func (n Network) IsHLPParent(address string) bool {
+	return n.HLPParent != "" && strings.EqualFold(n.HLPParent, address)
+}
+
+// WithNetwork selects the vaults endpoint and HLP addresses of a network. The stats and info URLs are
+// still given to NewClient, and the vaults endpoint can be overridden with WithVaultsURL, so that
+// they can be overridden independently. If neither the network nor WithVaultsURL gives a vaults
+// endpoint, FetchAllVault returns ErrVaultsUnavailable.
+func WithNetwork(network Network) Option {
+	return func(o *clientOptions) {
+		o.network = network
+	}
+}
@@ -0,0 +1,84 @@
+package hlstats_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+func TestLookupNetwork(t *testing.T) {
+	custom := map[string]hlstats.Network{
+		"testnet": {VaultsURL: "http://vaults.example.com"},
+		"staging": {InfoURL: "http://info.example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		want    hlstats.Network
+		wantErr bool
+	}{
+		{name: "", want: hlstats.Mainnet()},
+		{
+			name: " Testnet ",
+			want: hlstats.Network{
+				Name:      "testnet",
+				InfoURL:   hlstats.Testnet().InfoURL,
+				VaultsURL: "http://vaults.example.com",
+				HLPLeader: hlstats.Testnet().HLPLeader,
+				HLPParent: hlstats.Testnet().HLPParent,
+			},
+		},
+		{name: "staging", want: hlstats.Network{Name: "staging", InfoURL: "http://info.example.com"}},
+		{name: "devnet", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hlstats.LookupNetwork(tt.name, custom)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupNetwork() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LookupNetwork() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchAllVaultWithoutListing(t *testing.T) {
+	client := hlstats.NewClient("", hlstats.Testnet().InfoURL, hlstats.WithNetwork(hlstats.Testnet()))
+
+	if _, err := client.FetchAllVault(context.Background()); !errors.Is(err, hlstats.ErrVaultsUnavailable) {
+		t.Errorf("FetchAllVault() error = %v, want ErrVaultsUnavailable", err)
+	}
+}
+
+func TestWithVaultsURLOverridesNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[{"summary":{"name":"Alpha","vaultAddress":"0xa1","tvl":"100"}}]`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts []hlstats.Option
+	}{
+		{name: "network first", opts: []hlstats.Option{hlstats.WithNetwork(hlstats.Testnet()), hlstats.WithVaultsURL(server.URL)}},
+		{name: "network last", opts: []hlstats.Option{hlstats.WithVaultsURL(server.URL), hlstats.WithNetwork(hlstats.Testnet())}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := hlstats.NewClient("", hlstats.Testnet().InfoURL, append(tt.opts, hlstats.WithLimiter(nil))...)
+			vaults, err := client.FetchAllVault(context.Background())
+			if err != nil || len(vaults) != 1 {
+				t.Errorf("FetchAllVault() = %v, %v; want the vault of %s", vaults, err, server.URL)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package hlstats
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultVaultsURL is the stats-data endpoint listing all vaults.
+	DefaultVaultsURL = "https://stats-data.hyperliquid.xyz/Mainnet/vaults"
+
+	// DefaultUserAgent is sent with every request unless overridden with WithUserAgent.
+	DefaultUserAgent = "hyperliquid-stats"
+)
+
+// clientOptions collects the settings applied by Option values before the Client is assembled.
+type clientOptions struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+	limiter    Limiter
+	weights    RequestWeights
+	retry      RetryPolicy
+	vaultsURL  string
+	headers    http.Header
+	progress   ProgressReporter
+	network    Network
+	cache      *ResponseCache
+	cacheMode  CacheMode
+	recordDir  string
+}
+
+func defaultClientOptions() *clientOptions {
+	return &clientOptions{
+		timeout: defaultRequestTimeout,
+		limiter: NewLimiter(DefaultLimitWindows()...),
+		weights: RequestWeights{},
+		retry:   DefaultRetryPolicy(),
+		network: Mainnet(),
+		headers: http.Header{
+			"User-Agent": []string{DefaultUserAgent},
+		},
+	}
+}
+
+// resolvedVaultsURL returns the vaults endpoint set by WithVaultsURL, or else the network's.
+func (o *clientOptions) resolvedVaultsURL() string {
+	if o.vaultsURL != "" {
+		return o.vaultsURL
+	}
+
+	return o.network.VaultsURL
+}
+
+// Option configures a Client created by NewClient.
+type Option func(*clientOptions)
+
+// WithHTTPClient uses a copy of httpClient for all requests. Its transport (or http.DefaultTransport
+// if unset) is wrapped with the client's retry and rate limiting logic; its Timeout, if set, bounds
+// each call including retries.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sends requests through transport, e.g. a proxy-aware or test transport.
+// It takes precedence over the transport of a client given with WithHTTPClient.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout bounds every single HTTP attempt. Zero disables the per-attempt timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithLimiter replaces the client-side rate limiter. A nil limiter disables limiting.
+func WithLimiter(limiter Limiter) Option {
+	return func(o *clientOptions) {
+		o.limiter = limiter
+	}
+}
+
+// WithRequestWeights sets the weights charged to the limiter per request type.
+func WithRequestWeights(weights RequestWeights) Option {
+	return func(o *clientOptions) {
+		o.weights = weights
+	}
+}
+
+// WithRetryPolicy replaces the retry policy applied to every GET and POST request.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader sets a header sent with every request, replacing any previous value.
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) {
+		o.headers.Set(key, value)
+	}
+}
+
+// WithVaultsURL overrides the endpoint used by FetchAllVault. It takes precedence over the VaultsURL
+// of the network selected by WithNetwork, whatever the order of the options; an empty vaultsURL
+// keeps the network's.
+func WithVaultsURL(vaultsURL string) Option {
+	return func(o *clientOptions) {
+		o.vaultsURL = vaultsURL
+	}
+}
+
+// WithProgressReporter registers a reporter for vault fetch progress.
+func WithProgressReporter(reporter ProgressReporter) Option {
+	return func(o *clientOptions) {
+		o.progress = reporter
+	}
+}
+
+// WithCache serves responses from cache according to mode. Cached responses are not charged to the
+// limiter. Without this option, no response is cached.
+func WithCache(cache *ResponseCache, mode CacheMode) Option {
+	return func(o *clientOptions) {
+		o.cache = cache
+		o.cacheMode = mode
+	}
+}
@@ -0,0 +1,51 @@
+package hlstats
+
+import "github.com/pkg/errors"
+
+// Page selects a window of consecutive items of a list. The zero Page selects every item.
+type Page struct {
+	// Offset is the number of items skipped, from the start of the list or, if Bottom is set, from
+	// its end.
+	Offset int `json:"offset,omitempty"`
+	// Limit is the maximum number of items selected; 0 means no limit.
+	Limit int `json:"limit,omitempty"`
+	// Bottom takes the window from the end of the list instead of its start. Items keep their order.
+	Bottom bool `json:"bottom,omitempty"`
+}
+
+// FirstN returns the page of the first n items, or of every item if n is 0.
+func FirstN(n int) Page {
+	return Page{Limit: n}
+}
+
+// Validate returns an error if the offset or limit is negative.
+func (p Page) Validate() error {
+	if p.Offset < 0 {
+		return errors.Errorf("invalid offset %d: must not be negative", p.Offset)
+	}
+	if p.Limit < 0 {
+		return errors.Errorf("invalid limit %d: must not be negative", p.Limit)
+	}
+
+	return nil
+}
+
+// Bounds returns the half-open range [start, end) of the items of a list of n items selected by the page.
+func (p Page) Bounds(n int) (start, end int) {
+	offset := min(max(p.Offset, 0), n)
+	count := n - offset
+	if p.Limit > 0 {
+		count = min(count, p.Limit)
+	}
+
+	if p.Bottom {
+		return n - offset - count, n - offset
+	}
+	return offset, offset + count
+}
+
+// Paginate returns the items selected by page. The result shares the backing array of items.
+func Paginate[T any](items []T, page Page) []T {
+	start, end := page.Bounds(len(items))
+	return items[start:end]
+}
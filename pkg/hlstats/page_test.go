@@ -0,0 +1,56 @@
+package hlstats_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		page hlstats.Page
+		want []int
+	}{
+		{page: hlstats.Page{}, want: []int{1, 2, 3, 4, 5}},
+		{page: hlstats.FirstN(2), want: []int{1, 2}},
+		{page: hlstats.Page{Offset: 1, Limit: 2}, want: []int{2, 3}},
+		{page: hlstats.Page{Offset: 4, Limit: 3}, want: []int{5}},
+		{page: hlstats.Page{Offset: 7}, want: []int{}},
+		{page: hlstats.Page{Limit: 2, Bottom: true}, want: []int{4, 5}},
+		{page: hlstats.Page{Offset: 1, Limit: 2, Bottom: true}, want: []int{3, 4}},
+		{page: hlstats.Page{Offset: 3, Limit: 5, Bottom: true}, want: []int{1, 2}},
+		{page: hlstats.Page{Offset: 9, Bottom: true}, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v", tt.page), func(t *testing.T) {
+			got := hlstats.Paginate(items, tt.page)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageValidate(t *testing.T) {
+	tests := []struct {
+		page    hlstats.Page
+		wantErr bool
+	}{
+		{page: hlstats.Page{Offset: 1, Limit: 1}},
+		{page: hlstats.Page{Offset: -1}, wantErr: true},
+		{page: hlstats.Page{Limit: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v", tt.page), func(t *testing.T) {
+			if err := tt.page.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
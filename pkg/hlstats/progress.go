@@ -0,0 +1,48 @@
+package hlstats
+
+import "time"
+
+// ProgressEventType identifies a step of a concurrent vault fetch.
+type ProgressEventType string
+
+const (
+	VaultStarted ProgressEventType = "started"
+	VaultRetried ProgressEventType = "retried"
+	VaultDone    ProgressEventType = "done"
+	VaultFailed  ProgressEventType = "failed"
+)
+
+// ProgressEvent is emitted by FetchAllVaultVolumesConcurrent for every vault it processes.
+type ProgressEvent struct {
+	Type    ProgressEventType
+	Address string
+	Name    string
+	// Attempt is the 1-based attempt number of the request.
+	Attempt int
+	// Completed is the number of vaults done or failed so far; it is only set on VaultDone and VaultFailed.
+	Completed int
+	Total     int
+	// Elapsed is the time since the fetch started.
+	Elapsed time.Duration
+	// Err is set on VaultRetried and VaultFailed.
+	Err error
+}
+
+// ProgressReporter receives progress events. Events are delivered from the worker goroutines,
+// so implementations must be safe for concurrent use.
+type ProgressReporter interface {
+	OnProgress(event ProgressEvent)
+}
+
+// ProgressFunc adapts a function to the ProgressReporter interface.
+type ProgressFunc func(event ProgressEvent)
+
+func (f ProgressFunc) OnProgress(event ProgressEvent) {
+	f(event)
+}
+
+func (c *Client) reportProgress(event ProgressEvent) {
+	if c.progress != nil {
+		c.progress.OnProgress(event)
+	}
+}
@@ -0,0 +1,187 @@
+package hlstats
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// Recording is an HTTP exchange recorded with WithRecorder, stored as one indented JSON file per
+// request so that it can be reviewed and edited by hand.
+type Recording struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Payload is the JSON body of POST requests.
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Status  int             `json:"status"`
+	// Body is the response body if it is valid JSON, otherwise Text is.
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// recordingUnsafe matches the characters replaced in recording file names.
+var recordingUnsafe = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// recordingName returns the file name of the recording of req: its endpoint (see CacheTTLs)
+// followed by a hash of its method, path, query and compacted payload. The host is left out, so
+// recordings replay against any base URL.
+func recordingName(req *http.Request, payload []byte) string {
+	var compacted bytes.Buffer
+	if json.Compact(&compacted, payload) != nil {
+		compacted.Reset()
+		compacted.Write(payload)
+	}
+
+	h := sha256.New()
+	io.WriteString(h, req.Method+"\n"+req.URL.RequestURI()+"\n")
+	h.Write(compacted.Bytes())
+
+	endpoint := recordingUnsafe.ReplaceAllString(cacheEndpoint(req), "_")
+	return endpoint + "-" + hex.EncodeToString(h.Sum(nil))[:16] + ".json"
+}
+
+// requestPayload returns a copy of the body of req, leaving req untouched.
+func requestPayload(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+	defer body.Close()
+
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+
+	return payload, nil
+}
+
+// WithRecorder writes every response received by the client, whatever its status, to a recording
+// file in dir, which is created if needed. The recordings can be served by NewReplayTransport.
+func WithRecorder(dir string) Option {
+	return func(o *clientOptions) {
+		o.recordDir = dir
+	}
+}
+
+// recordTransport is an http.RoundTripper writing the responses of its base transport to recordings.
+type recordTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := requestPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recording := Recording{Method: req.Method, URL: req.URL.String(), Status: resp.StatusCode}
+	if len(payload) > 0 && json.Valid(payload) {
+		recording.Payload = payload
+	}
+	if json.Valid(body) {
+		recording.Body = body
+	} else {
+		recording.Text = string(body)
+	}
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode recording")
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create recording directory %s", t.dir)
+	}
+	path := filepath.Join(t.dir, recordingName(req, payload))
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return nil, errors.Wrapf(err, "failed to write recording %s", path)
+	}
+
+	return resp, nil
+}
+
+// replayTransport is an http.RoundTripper serving recordings instead of sending requests.
+type replayTransport struct {
+	dir string
+}
+
+// NewReplayTransport returns a transport that serves the recordings in dir, written by WithRecorder,
+// instead of sending requests; use it with WithTransport. Requests are matched by method, path,
+// query and payload. Requests without a recording get a 404 response naming the missing recording,
+// which the default retry policy does not retry.
+func NewReplayTransport(dir string) http.RoundTripper {
+	return &replayTransport{dir: dir}
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := requestPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	name := recordingName(req, payload)
+	data, err := os.ReadFile(filepath.Join(t.dir, name))
+	if os.IsNotExist(err) {
+		text := fmt.Sprintf("no recorded response for %s %s (%s in %s)", req.Method, req.URL.RequestURI(), name, t.dir)
+		return replayResponse(req, http.StatusNotFound, "text/plain", []byte(text)), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read recording %s", name)
+	}
+
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, errors.Wrapf(err, "invalid recording %s", name)
+	}
+
+	body := []byte(recording.Body)
+	if len(body) == 0 {
+		body = []byte(recording.Text)
+	}
+
+	return replayResponse(req, recording.Status, "application/json", body), nil
+}
+
+// replayResponse returns a response to req with the given status, content type and body.
+func replayResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,185 @@
+package hlstats_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+// newTestServer serves the leaderboard, a vault listing, the details of vault 0x1 and a failing
+// daily volume endpoint, and counts the requests it receives.
+func newTestServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/largest_users_by_usd_volume", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		io.WriteString(w, `{"table_data":[{"name":"0xabc","value":1500000.5},{"name":"0xdef","value":250000}]}`)
+	})
+	mux.HandleFunc("/vaults", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		io.WriteString(w, `[{"summary":{"name":"Alpha","vaultAddress":"0x1","leader":"0x3","tvl":"1200.5","isClosed":false}}]`)
+	})
+	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var payload hlstats.VaultVolumeRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Address != "0x1" {
+			http.Error(w, "unknown vault", http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{"name":"Alpha","vaultAddress":"0x1","portfolio":[["day",{"vlm":"100.0"}],["week",{"vlm":"700.0"}]]}`)
+	})
+	mux.HandleFunc("/daily_usd_volume", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fetchAll fetches the endpoints of newTestServer and returns what it got.
+func fetchAll(t *testing.T, client *hlstats.Client) (hlstats.USDVolumeByUsers, hlstats.Vaults, hlstats.VaultDetails) {
+	t.Helper()
+	ctx := context.Background()
+
+	users, err := client.FetchLargestUsers(ctx)
+	if err != nil {
+		t.Fatalf("FetchLargestUsers() error = %v", err)
+	}
+	vaults, err := client.FetchAllVault(ctx)
+	if err != nil {
+		t.Fatalf("FetchAllVault() error = %v", err)
+	}
+	details, err := client.FetchVaultDetails(ctx, "0x1")
+	if err != nil {
+		t.Fatalf("FetchVaultDetails() error = %v", err)
+	}
+
+	return users, vaults, details
+}
+
+func TestRecordReplay(t *testing.T) {
+	var requests atomic.Int32
+	server := newTestServer(t, &requests)
+	dir := filepath.Join(t.TempDir(), "recordings")
+	noRetry := hlstats.WithRetryPolicy(hlstats.RetryPolicy{MaxAttempts: 1})
+
+	recorder := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithVaultsURL(server.URL+"/vaults"),
+		hlstats.WithLimiter(nil),
+		hlstats.WithRecorder(dir),
+		noRetry,
+	)
+	wantUsers, wantVaults, wantDetails := fetchAll(t, recorder)
+	_, wantErr := recorder.FetchDailyVolume(context.Background(), nil, nil)
+	if wantErr == nil {
+		t.Fatalf("FetchDailyVolume() error = nil, want the recorded status error")
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 4 {
+		t.Fatalf("recorded %d files (%v), want 4", len(files), err)
+	}
+	recorded := requests.Load()
+
+	// The host of the recordings is ignored, so they replay against any base URL
+	base := "http://replay.invalid"
+	replayer := hlstats.NewClient(base, base+"/info",
+		hlstats.WithVaultsURL(base+"/vaults"),
+		hlstats.WithLimiter(nil),
+		hlstats.WithTransport(hlstats.NewReplayTransport(dir)),
+		noRetry,
+	)
+	users, vaults, details := fetchAll(t, replayer)
+	if !reflect.DeepEqual(users, wantUsers) {
+		t.Errorf("replayed users = %+v, want %+v", users, wantUsers)
+	}
+	if !reflect.DeepEqual(vaults, wantVaults) {
+		t.Errorf("replayed vaults = %+v, want %+v", vaults, wantVaults)
+	}
+	if !reflect.DeepEqual(details, wantDetails) {
+		t.Errorf("replayed details = %+v, want %+v", details, wantDetails)
+	}
+
+	_, err = replayer.FetchDailyVolume(context.Background(), nil, nil)
+	var statusErr *hlstats.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("replayed FetchDailyVolume() error = %v, want status %d", err, http.StatusServiceUnavailable)
+	}
+
+	if got := requests.Load(); got != recorded {
+		t.Errorf("replay sent %d requests to the server", got-recorded)
+	}
+}
+
+func TestReplayNotRecorded(t *testing.T) {
+	dir := t.TempDir()
+	client := hlstats.NewClient("http://replay.invalid", "http://replay.invalid/info",
+		hlstats.WithLimiter(nil),
+		hlstats.WithTransport(hlstats.NewReplayTransport(dir)),
+		// Missing recordings must not be retried by the default statuses
+		hlstats.WithRetryPolicy(hlstats.RetryPolicy{
+			MaxAttempts:       5,
+			BaseDelay:         time.Hour,
+			RetryableStatuses: hlstats.DefaultRetryPolicy().RetryableStatuses,
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := client.FetchVaultDetails(ctx, "0x2")
+	var statusErr *hlstats.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound ||
+		!strings.Contains(statusErr.Body, "no recorded response for POST /info") {
+		t.Fatalf("FetchVaultDetails() error = %v, want a 404 naming the missing recording", err)
+	}
+}
+
+func TestRecordingFormat(t *testing.T) {
+	var requests atomic.Int32
+	server := newTestServer(t, &requests)
+	dir := t.TempDir()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info", hlstats.WithLimiter(nil), hlstats.WithRecorder(dir))
+	if _, err := client.FetchVaultDetails(context.Background(), "0x1"); err != nil {
+		t.Fatalf("FetchVaultDetails() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "vaultDetails-*.json"))
+	if len(files) != 1 {
+		t.Fatalf("recorded %v, want one vaultDetails recording", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var recording hlstats.Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		t.Fatalf("invalid recording: %v", err)
+	}
+	if recording.Method != http.MethodPost || recording.URL != server.URL+"/info" || recording.Status != http.StatusOK {
+		t.Errorf("recording = %s %s %d, want POST %s/info 200", recording.Method, recording.URL, recording.Status, server.URL)
+	}
+
+	var payload hlstats.VaultVolumeRequest
+	if err := json.Unmarshal(recording.Payload, &payload); err != nil || payload.Type != "vaultDetails" || payload.Address != "0x1" {
+		t.Errorf("recording payload = %s, want the vaultDetails request of 0x1", recording.Payload)
+	}
+	if !json.Valid(recording.Body) || recording.Text != "" {
+		t.Errorf("recording body = %q, text = %q, want the JSON body", recording.Body, recording.Text)
+	}
+}
@@ -0,0 +1,235 @@
+package hlstats
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy controls how failed HTTP requests are retried by the Client.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the first one.
+	// Values below 1 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between two attempts, including delays requested via Retry-After.
+	MaxDelay time.Duration
+	// RetryableStatuses lists the HTTP status codes that are retried.
+	RetryableStatuses []int
+}
+
+// DefaultRetryPolicy returns the policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// IsRetryableStatus reports whether the policy retries the given HTTP status code.
+func (p RetryPolicy) IsRetryableStatus(statusCode int) bool {
+	for _, s := range p.RetryableStatuses {
+		if s == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Backoff returns the delay before the given retry (1 for the first retry), using exponential
+// backoff with equal jitter: a random duration in [d/2, d) where d = BaseDelay * 2^(retry-1),
+// capped at MaxDelay.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// retryTransport is an http.RoundTripper that applies a RetryPolicy, a per-attempt timeout and
+// waits for the limiter before every attempt.
+type retryTransport struct {
+	base    http.RoundTripper
+	policy  RetryPolicy
+	timeout time.Duration
+	limiter Limiter
+	weights RequestWeights
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxAttempts := t.policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	// Requests whose body cannot be replayed are only attempted once.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxAttempts = 1
+	}
+
+	weight := t.weights.Weight(requestType(req))
+
+	for attempt := 1; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(req.Context(), weight); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq, cancel, err := t.prepare(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err == nil {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		} else {
+			cancel()
+		}
+
+		// The caller gave up; do not retry.
+		if req.Context().Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+
+		retryable := err != nil || t.policy.IsRetryableStatus(resp.StatusCode)
+		if !retryable || attempt >= maxAttempts {
+			return resp, err
+		}
+
+		delay := t.policy.Backoff(attempt)
+		cause := err
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > delay {
+				delay = retryAfter
+			}
+			if t.policy.MaxDelay > 0 && delay > t.policy.MaxDelay {
+				delay = t.policy.MaxDelay
+			}
+			// Drain the body so that the connection can be reused.
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			cause = newStatusError(resp, body)
+		}
+		if observe, ok := req.Context().Value(retryObserverKey{}).(retryObserver); ok {
+			observe(attempt+1, cause)
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryObserver is notified before every retry of the requests made with its context, with the
+// number of the next attempt and the error of the failed one.
+type retryObserver func(attempt int, err error)
+
+type retryObserverKey struct{}
+
+// withRetryObserver returns a copy of ctx whose requests notify observe of their retries.
+func withRetryObserver(ctx context.Context, observe retryObserver) context.Context {
+	return context.WithValue(ctx, retryObserverKey{}, observe)
+}
+
+// prepare returns a copy of req for the given attempt, with a fresh body and the per-attempt timeout applied.
+func (t *retryTransport) prepare(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, errors.Wrap(err, "failed to rewind request body")
+		}
+		attemptReq.Body = body
+	}
+
+	return attemptReq, cancel, nil
+}
+
+// cancelOnClose releases the per-attempt context once the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// RetryPolicy returns the retry policy applied to every request.
+func (c *Client) RetryPolicy() RetryPolicy {
+	return c.transport.policy
+}
+
+// IsPermanent reports whether err is an API error that retrying will not fix, i.e. an HTTP status
+// that is not part of the client's retry policy.
+func (c *Client) IsPermanent(err error) bool {
+	code := statusCodeOf(err)
+	return code != 0 && !c.transport.policy.IsRetryableStatus(code)
+}
@@ -0,0 +1,125 @@
+package hlstats_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := hlstats.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name     string
+		policy   hlstats.RetryPolicy
+		retry    int
+		min, max time.Duration
+	}{
+		{name: "first retry", policy: policy, retry: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "doubles", policy: policy, retry: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "fourth retry", policy: policy, retry: 4, min: 400 * time.Millisecond, max: 800 * time.Millisecond},
+		{name: "capped", policy: policy, retry: 5, min: 500 * time.Millisecond, max: time.Second},
+		{name: "capped without overflow", policy: policy, retry: 100, min: 500 * time.Millisecond, max: time.Second},
+		{
+			name:   "no cap",
+			policy: hlstats.RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			retry:  6,
+			min:    1600 * time.Millisecond,
+			max:    3200 * time.Millisecond,
+		},
+		{name: "no base delay", policy: hlstats.RetryPolicy{MaxDelay: time.Second}, retry: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The jitter is random; every sample must stay within [min, max), or be 0 without a delay.
+			for i := 0; i < 200; i++ {
+				got := tt.policy.Backoff(tt.retry)
+				if tt.max == 0 && got != 0 {
+					t.Fatalf("Backoff(%d) = %v, want 0", tt.retry, got)
+				}
+				if tt.max > 0 && (got < tt.min || got >= tt.max) {
+					t.Fatalf("Backoff(%d) = %v, want in [%v, %v)", tt.retry, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+	}{
+		{name: "seconds", retryAfter: "3600"},
+		{name: "http date", retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)},
+		{name: "invalid", retryAfter: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					http.Error(w, "slow down", http.StatusTooManyRequests)
+					return
+				}
+				io.WriteString(w, `{"table_data":[{"name":"0xabc","value":1}]}`)
+			}))
+			t.Cleanup(server.Close)
+
+			client := hlstats.NewClient(server.URL, server.URL+"/info",
+				hlstats.WithLimiter(nil),
+				hlstats.WithRetryPolicy(hlstats.RetryPolicy{
+					MaxAttempts:       2,
+					BaseDelay:         time.Millisecond,
+					MaxDelay:          20 * time.Millisecond,
+					RetryableStatuses: []int{http.StatusTooManyRequests},
+				}),
+			)
+
+			// The hour requested by the server would hit the deadline; MaxDelay caps it.
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := client.FetchLargestUsers(ctx); err != nil {
+				t.Fatalf("FetchLargestUsers() error = %v", err)
+			}
+			if got := requests.Load(); got != 2 {
+				t.Errorf("sent %d requests, want 2", got)
+			}
+		})
+	}
+}
+
+func TestClientIsPermanent(t *testing.T) {
+	client := hlstats.NewClient("", "", hlstats.WithRetryPolicy(hlstats.DefaultRetryPolicy()))
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "not found", err: &hlstats.StatusError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "wrapped bad request", err: errors.Wrap(&hlstats.StatusError{StatusCode: http.StatusBadRequest}, "fetch"), want: true},
+		{name: "unavailable", err: &hlstats.StatusError{StatusCode: http.StatusServiceUnavailable}},
+		{name: "rate limited", err: &hlstats.RateLimitedError{Status: &hlstats.StatusError{StatusCode: http.StatusTooManyRequests}}},
+		{name: "network error", err: errors.New("connection reset")},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.IsPermanent(tt.err); got != tt.want {
+				t.Errorf("IsPermanent(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package hlstats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SortOrder is the direction of a sort key.
+type SortOrder int
+
+const (
+	// SortDefault sorts in the default direction of the field.
+	SortDefault SortOrder = iota
+	SortAsc
+	SortDesc
+)
+
+// SortKey is a field to sort by, with its direction.
+type SortKey struct {
+	Field string
+	Order SortOrder
+}
+
+func (k SortKey) String() string {
+	switch k.Order {
+	case SortAsc:
+		return k.Field + ":asc"
+	case SortDesc:
+		return k.Field + ":desc"
+	default:
+		return k.Field
+	}
+}
+
+// SortKeys is a list of sort keys; later keys break the ties of earlier ones.
+type SortKeys []SortKey
+
+// ParseSortKeys parses a sort expression: a comma-separated list of fields, each optionally followed
+// by ":asc" or ":desc", e.g. "hlp:desc,tvl:desc,name:asc". Fields are not validated here, since they
+// depend on what is sorted; see SortFields.Sort.
+func ParseSortKeys(expr string) (SortKeys, error) {
+	var keys SortKeys
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field, order, hasOrder := strings.Cut(part, ":")
+		key := SortKey{Field: strings.ToLower(strings.TrimSpace(field))}
+		if key.Field == "" {
+			return nil, errors.Errorf("invalid sort key %q: missing field", part)
+		}
+		if hasOrder {
+			switch strings.ToLower(strings.TrimSpace(order)) {
+			case "asc":
+				key.Order = SortAsc
+			case "desc":
+				key.Order = SortDesc
+			default:
+				return nil, errors.Errorf("invalid sort key %q: order must be asc or desc", part)
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (keys SortKeys) String() string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key.String())
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Has reports whether the keys sort by field.
+func (keys SortKeys) Has(field string) bool {
+	return slices.ContainsFunc(keys, func(k SortKey) bool { return k.Field == field })
+}
+
+// WithDefaultOrder returns the keys with every key without explicit direction set to order.
+func (keys SortKeys) WithDefaultOrder(order SortOrder) SortKeys {
+	result := make(SortKeys, len(keys))
+	for i, key := range keys {
+		if key.Order == SortDefault {
+			key.Order = order
+		}
+		result[i] = key
+	}
+
+	return result
+}
+
+// SortField is a sortable field of T.
+type SortField[T any] struct {
+	// Compare returns a negative number if a sorts before b in ascending order, a positive number if
+	// it sorts after, and 0 if they are equal.
+	Compare func(a, b T) int
+	// Desc makes descending the default direction of the field.
+	Desc bool
+	// Undefined, if set, reports whether the field has no value; such elements sort after all
+	// others in either direction.
+	Undefined func(a T) bool
+}
+
+// SortFields maps field names to the sortable fields of T.
+type SortFields[T any] map[string]SortField[T]
+
+// Names returns the field names, sorted.
+func (fields SortFields[T]) Names() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Validate returns an error if a key refers to an unknown field.
+func (fields SortFields[T]) Validate(keys SortKeys) error {
+	for _, key := range keys {
+		if _, ok := fields[key.Field]; !ok {
+			return errors.Errorf("unknown sort key '%s'. Valid options: %s", key.Field, strings.Join(fields.Names(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// Sort returns a copy of data stably sorted by keys. An error is returned if a key refers to an
+// unknown field.
+func (fields SortFields[T]) Sort(data []T, keys SortKeys) ([]T, error) {
+	if err := fields.Validate(keys); err != nil {
+		return nil, err
+	}
+
+	compares := make([]func(a, b T) int, 0, len(keys))
+	for _, key := range keys {
+		field := fields[key.Field]
+		desc := key.Order == SortDesc || (key.Order == SortDefault && field.Desc)
+		compare := field.Compare
+		if desc {
+			compare = func(a, b T) int { return field.Compare(b, a) }
+		}
+		if field.Undefined != nil {
+			compare = undefinedLast(field.Undefined, compare)
+		}
+		compares = append(compares, compare)
+	}
+
+	result := slices.Clone(data)
+	slices.SortStableFunc(result, func(a, b T) int {
+		for _, compare := range compares {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	return result, nil
+}
+
+// Helpers for building sort fields
+
+// undefinedLast wraps compare to sort the elements for which undefined reports true last.
+func undefinedLast[T any](undefined func(T) bool, compare func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		ua, ub := undefined(a), undefined(b)
+		switch {
+		case ua && ub:
+			return 0
+		case ua:
+			return 1
+		case ub:
+			return -1
+		default:
+			return compare(a, b)
+		}
+	}
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func compareFold(a, b string) int {
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func numberField[T any](value func(T) float64) SortField[T] {
+	return SortField[T]{
+		Compare: func(a, b T) int { return cmp.Compare(value(a), value(b)) },
+		Desc:    true,
+	}
+}
+
+func textField[T any](value func(T) string) SortField[T] {
+	return SortField[T]{Compare: func(a, b T) int { return compareFold(value(a), value(b)) }}
+}
+
+func boolField[T any](value func(T) bool) SortField[T] {
+	return SortField[T]{
+		Compare: func(a, b T) int { return compareBool(value(a), value(b)) },
+		Desc:    true,
+	}
+}
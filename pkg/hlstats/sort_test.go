@@ -0,0 +1,98 @@
+package hlstats_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    hlstats.SortKeys
+		wantErr bool
+	}{
+		{expr: "", want: nil},
+		{expr: "tvl", want: hlstats.SortKeys{{Field: "tvl"}}},
+		{
+			expr: "hlp:desc, TVL:ASC ,name",
+			want: hlstats.SortKeys{{Field: "hlp", Order: hlstats.SortDesc}, {Field: "tvl", Order: hlstats.SortAsc}, {Field: "name"}},
+		},
+		{expr: "tvl,,name", want: hlstats.SortKeys{{Field: "tvl"}, {Field: "name"}}},
+		{expr: ":asc", wantErr: true},
+		{expr: "tvl:up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := hlstats.ParseSortKeys(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSortKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSortKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	keys := hlstats.SortKeys{{Field: "hlp", Order: hlstats.SortDesc}, {Field: "tvl"}, {Field: "name", Order: hlstats.SortAsc}}
+
+	if got, want := keys.String(), "hlp:desc,tvl,name:asc"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if !keys.Has("tvl") || keys.Has("apr") {
+		t.Errorf("Has() does not match the keys %v", keys)
+	}
+
+	got := keys.WithDefaultOrder(hlstats.SortAsc)
+	want := hlstats.SortKeys{{Field: "hlp", Order: hlstats.SortDesc}, {Field: "tvl", Order: hlstats.SortAsc}, {Field: "name", Order: hlstats.SortAsc}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithDefaultOrder() = %v, want %v", got, want)
+	}
+	if keys[1].Order != hlstats.SortDefault {
+		t.Errorf("WithDefaultOrder() modified the keys")
+	}
+}
+
+func TestSortFieldsValidate(t *testing.T) {
+	tests := []struct {
+		keys    hlstats.SortKeys
+		wantErr bool
+	}{
+		{keys: nil},
+		{keys: hlstats.SortKeys{{Field: "tvl"}, {Field: "age", Order: hlstats.SortAsc}}},
+		{keys: hlstats.SortKeys{{Field: "tvl"}, {Field: "volume"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keys.String(), func(t *testing.T) {
+			err := hlstats.VaultSortFields.Validate(tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSortFieldsSortIsStable(t *testing.T) {
+	vaults := hlstats.Vaults{
+		{Data: hlstats.VaultSummary{Name: "a", TVL: 1}},
+		{Data: hlstats.VaultSummary{Name: "b", TVL: 2}},
+		{Data: hlstats.VaultSummary{Name: "c", TVL: 1}},
+		{Data: hlstats.VaultSummary{Name: "d", TVL: 2}},
+	}
+
+	got, err := hlstats.VaultSortFields.Sort(vaults, hlstats.SortKeys{{Field: "tvl"}})
+	if err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+	if want := []string{"b", "d", "a", "c"}; !reflect.DeepEqual(vaultNames(got), want) {
+		t.Errorf("Sort() = %v, want %v", vaultNames(got), want)
+	}
+	if vaults[0].Data.Name != "a" || vaults[1].Data.Name != "b" {
+		t.Errorf("Sort() modified its input")
+	}
+}
@@ -0,0 +1,299 @@
+package hlstats
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Vault relationship types reported by vaultDetails.
+const (
+	RelationshipNormal = "normal"
+	RelationshipParent = "parent"
+	RelationshipChild  = "child"
+)
+
+// Portfolio period names reported by vaultDetails, in display order.
+var PortfolioPeriods = []string{
+	"day", "week", "month", "allTime",
+	"perpDay", "perpWeek", "perpMonth", "perpAllTime",
+}
+
+// HistoryPoint is a single sample of an account value or PnL time series.
+type HistoryPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+func (p *HistoryPoint) UnmarshalJSON(data []byte) error {
+	var tmp []json.RawMessage
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	if len(tmp) != 2 {
+		return errors.Errorf("invalid history point %s", data)
+	}
+
+	var ms int64
+	if err := json.Unmarshal(tmp[0], &ms); err != nil {
+		return errors.Wrap(err, "invalid history point time")
+	}
+	value, err := parseFloatField(tmp[1])
+	if err != nil {
+		return errors.Wrap(err, "invalid history point value")
+	}
+
+	p.Time = time.UnixMilli(ms).UTC()
+	p.Value = value
+
+	return nil
+}
+
+// History is a time series ordered by time.
+type History []HistoryPoint
+
+// Last returns the most recent value of the series, or 0 if it is empty.
+func (h History) Last() float64 {
+	if len(h) == 0 {
+		return 0
+	}
+
+	return h[len(h)-1].Value
+}
+
+// PortfolioPeriod holds the volume and the account value and PnL time series of a vault over one period.
+type PortfolioPeriod struct {
+	Volume              float64 `json:"volume"`
+	AccountValueHistory History `json:"accountValueHistory"`
+	PnLHistory          History `json:"pnlHistory"`
+}
+
+func (p *PortfolioPeriod) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		AccountValueHistory History         `json:"accountValueHistory"`
+		PnLHistory          History         `json:"pnlHistory"`
+		Volume              json.RawMessage `json:"vlm"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	volume, err := parseFloatField(tmp.Volume)
+	if err != nil {
+		return errors.Wrap(err, "invalid vlm")
+	}
+
+	p.Volume = volume
+	p.AccountValueHistory = tmp.AccountValueHistory
+	p.PnLHistory = tmp.PnLHistory
+
+	return nil
+}
+
+// VaultPortfolio holds the portfolio of a vault per period. The Perp periods only count perpetuals.
+type VaultPortfolio struct {
+	Day     PortfolioPeriod `json:"day"`
+	Week    PortfolioPeriod `json:"week"`
+	Month   PortfolioPeriod `json:"month"`
+	AllTime PortfolioPeriod `json:"allTime"`
+
+	PerpDay     PortfolioPeriod `json:"perpDay"`
+	PerpWeek    PortfolioPeriod `json:"perpWeek"`
+	PerpMonth   PortfolioPeriod `json:"perpMonth"`
+	PerpAllTime PortfolioPeriod `json:"perpAllTime"`
+}
+
+// The API encodes the portfolio as a list of [period, data] pairs.
+func (p *VaultPortfolio) UnmarshalJSON(data []byte) error {
+	var tmp [][2]json.RawMessage
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	for _, item := range tmp {
+		var name string
+		if err := json.Unmarshal(item[0], &name); err != nil {
+			return errors.Wrap(err, "invalid portfolio period name")
+		}
+
+		period := p.Period(name)
+		if period == nil {
+			return errors.Errorf("unknown portfolio period %q", name)
+		}
+		if err := json.Unmarshal(item[1], period); err != nil {
+			return errors.Wrapf(err, "invalid portfolio period %q", name)
+		}
+	}
+
+	return nil
+}
+
+// Period returns the portfolio of the named period (see PortfolioPeriods), or nil if the name is unknown.
+func (p *VaultPortfolio) Period(name string) *PortfolioPeriod {
+	switch name {
+	case "day":
+		return &p.Day
+	case "week":
+		return &p.Week
+	case "month":
+		return &p.Month
+	case "allTime":
+		return &p.AllTime
+	case "perpDay":
+		return &p.PerpDay
+	case "perpWeek":
+		return &p.PerpWeek
+	case "perpMonth":
+		return &p.PerpMonth
+	case "perpAllTime":
+		return &p.PerpAllTime
+	default:
+		return nil
+	}
+}
+
+// Volume returns the volume of every period, including the derived spot volumes.
+func (p VaultPortfolio) Volume() VaultVolume {
+	v := VaultVolume{
+		Day:         p.Day.Volume,
+		Week:        p.Week.Volume,
+		Month:       p.Month.Volume,
+		AllTime:     p.AllTime.Volume,
+		PerpDay:     p.PerpDay.Volume,
+		PerpWeek:    p.PerpWeek.Volume,
+		PerpMonth:   p.PerpMonth.Volume,
+		PerpAllTime: p.PerpAllTime.Volume,
+	}
+	v.deriveSpot()
+
+	return v
+}
+
+// VaultFollower is a depositor of a vault.
+type VaultFollower struct {
+	User          string    `json:"user"`
+	VaultEquity   float64   `json:"vaultEquity"`
+	PnL           float64   `json:"pnl"`
+	AllTimePnL    float64   `json:"allTimePnl"`
+	DaysFollowing int       `json:"daysFollowing"`
+	EntryTime     time.Time `json:"vaultEntryTime"`
+	LockupUntil   time.Time `json:"lockupUntil"`
+}
+
+func (f *VaultFollower) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		User          string `json:"user"`
+		VaultEquity   string `json:"vaultEquity"`
+		PnL           string `json:"pnl"`
+		AllTimePnL    string `json:"allTimePnl"`
+		DaysFollowing int    `json:"daysFollowing"`
+		EntryTime     int64  `json:"vaultEntryTime"`
+		LockupUntil   int64  `json:"lockupUntil"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	f.User = tmp.User
+	f.VaultEquity, _ = strconv.ParseFloat(tmp.VaultEquity, 64)
+	f.PnL, _ = strconv.ParseFloat(tmp.PnL, 64)
+	f.AllTimePnL, _ = strconv.ParseFloat(tmp.AllTimePnL, 64)
+	f.DaysFollowing = tmp.DaysFollowing
+	f.EntryTime = unixMilli(tmp.EntryTime)
+	f.LockupUntil = unixMilli(tmp.LockupUntil)
+
+	return nil
+}
+
+// VaultRelationship links a vault to its parent or child vaults.
+type VaultRelationship struct {
+	Type           string   `json:"type"`
+	ParentAddress  string   `json:"parentAddress,omitempty"`
+	ChildAddresses []string `json:"childAddresses,omitempty"`
+}
+
+func (r *VaultRelationship) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Type string `json:"type"`
+		Data struct {
+			ParentAddress  string   `json:"parentAddress"`
+			ChildAddresses []string `json:"childAddresses"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	r.Type = tmp.Type
+	r.ParentAddress = tmp.Data.ParentAddress
+	r.ChildAddresses = tmp.Data.ChildAddresses
+
+	return nil
+}
+
+// VaultDetails is the full vaultDetails response of the info endpoint.
+type VaultDetails struct {
+	Name                  string            `json:"name"`
+	Address               string            `json:"vaultAddress"`
+	Leader                string            `json:"leader"`
+	Description           string            `json:"description"`
+	Portfolio             VaultPortfolio    `json:"portfolio"`
+	APR                   float64           `json:"apr"`
+	Followers             VaultFollowers    `json:"followers"`
+	LeaderFraction        float64           `json:"leaderFraction"`
+	LeaderCommission      float64           `json:"leaderCommission"`
+	MaxDistributable      float64           `json:"maxDistributable"`
+	MaxWithdrawable       float64           `json:"maxWithdrawable"`
+	Closed                bool              `json:"isClosed"`
+	Relationship          VaultRelationship `json:"relationship"`
+	AllowDeposits         bool              `json:"allowDeposits"`
+	AlwaysCloseOnWithdraw bool              `json:"alwaysCloseOnWithdraw"`
+}
+
+// FetchVaultDetails returns the details of a single vault.
+func (c *Client) FetchVaultDetails(ctx context.Context, vaultAddress string) (VaultDetails, error) {
+	var result VaultDetails
+
+	payload := VaultVolumeRequest{
+		Type:    "vaultDetails",
+		Address: vaultAddress,
+	}
+
+	err := c.PostRequest(ctx, c.infoURL, payload, &result)
+	if err != nil {
+		return VaultDetails{}, errors.Wrapf(err, "failed to fetch vault details for address %s", vaultAddress)
+	}
+
+	return result, nil
+}
+
+// parseFloatField parses a JSON number or a JSON string holding a number. Null and empty values are 0.
+func parseFloatField(data json.RawMessage) (float64, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return 0, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if s == "" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(s, 64)
+	}
+
+	var f float64
+	err := json.Unmarshal(data, &f)
+	return f, err
+}
+
+// unixMilli converts a millisecond timestamp to UTC time. Zero stays the zero time.
+func unixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(ms).UTC()
+}
@@ -0,0 +1,239 @@
+package hlstats_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestHistoryPointUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.HistoryPoint
+		wantErr bool
+	}{
+		{
+			name: "string value",
+			data: `[1734000000000, "1000.5"]`,
+			want: hlstats.HistoryPoint{Time: time.UnixMilli(1734000000000).UTC(), Value: 1000.5},
+		},
+		{
+			name: "number value",
+			data: `[1734000000000, -2.25]`,
+			want: hlstats.HistoryPoint{Time: time.UnixMilli(1734000000000).UTC(), Value: -2.25},
+		},
+		{
+			name: "empty value",
+			data: `[1734000000000, ""]`,
+			want: hlstats.HistoryPoint{Time: time.UnixMilli(1734000000000).UTC()},
+		},
+		{name: "missing value", data: `[1734000000000]`, wantErr: true},
+		{name: "invalid time", data: `["yesterday", "1"]`, wantErr: true},
+		{name: "invalid value", data: `[1734000000000, "abc"]`, wantErr: true},
+		{name: "object", data: `{"time": 1}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.HistoryPoint
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortfolioPeriodUnmarshalJSON(t *testing.T) {
+	t0 := time.UnixMilli(1734000000000).UTC()
+	t1 := time.UnixMilli(1734086400000).UTC()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.PortfolioPeriod
+		wantErr bool
+	}{
+		{
+			name: "full",
+			data: `{"accountValueHistory":[[1734000000000,"1000"],[1734086400000,"1010"]],"pnlHistory":[[1734000000000,"0"],[1734086400000,"10"]],"vlm":"5000.0"}`,
+			want: hlstats.PortfolioPeriod{
+				Volume:              5000,
+				AccountValueHistory: hlstats.History{{Time: t0, Value: 1000}, {Time: t1, Value: 1010}},
+				PnLHistory:          hlstats.History{{Time: t0, Value: 0}, {Time: t1, Value: 10}},
+			},
+		},
+		{
+			name: "numeric volume",
+			data: `{"vlm":12.5}`,
+			want: hlstats.PortfolioPeriod{Volume: 12.5},
+		},
+		{
+			name: "null volume",
+			data: `{"vlm":null}`,
+			want: hlstats.PortfolioPeriod{},
+		},
+		{name: "invalid volume", data: `{"vlm":"many"}`, wantErr: true},
+		{name: "invalid history", data: `{"pnlHistory":[[1]]}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.PortfolioPeriod
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultPortfolioUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.VaultVolume
+		wantErr bool
+	}{
+		{
+			name: "all periods",
+			data: `[["day",{"vlm":"1"}],["week",{"vlm":"2"}],["month",{"vlm":"3"}],["allTime",{"vlm":"4"}],
+				["perpDay",{"vlm":"0.5"}],["perpWeek",{"vlm":"2"}],["perpMonth",{"vlm":"1"}],["perpAllTime",{"vlm":"5"}]]`,
+			want: hlstats.VaultVolume{
+				Day: 1, Week: 2, Month: 3, AllTime: 4,
+				PerpDay: 0.5, PerpWeek: 2, PerpMonth: 1, PerpAllTime: 5,
+				SpotDay: 0.5, SpotWeek: 0, SpotMonth: 2, SpotAllTime: 0,
+			},
+		},
+		{
+			name: "some periods",
+			data: `[["week",{"vlm":"700"}]]`,
+			want: hlstats.VaultVolume{Week: 700, SpotWeek: 700},
+		},
+		{name: "empty", data: `[]`},
+		{name: "unknown period", data: `[["hour",{"vlm":"1"}]]`, wantErr: true},
+		{name: "invalid period name", data: `[[1,{"vlm":"1"}]]`, wantErr: true},
+		{name: "invalid period", data: `[["day",{"vlm":"x"}]]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.VaultPortfolio
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Volume() != tt.want {
+				t.Errorf("Unmarshal().Volume() = %+v, want %+v", got.Volume(), tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultPortfolioPeriod(t *testing.T) {
+	var portfolio hlstats.VaultPortfolio
+	for _, name := range hlstats.PortfolioPeriods {
+		if portfolio.Period(name) == nil {
+			t.Errorf("Period(%q) = nil", name)
+		}
+	}
+	if portfolio.Period("allTimes") != nil {
+		t.Errorf("Period(%q) != nil", "allTimes")
+	}
+}
+
+func TestVaultFollowerUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.VaultFollower
+		wantErr bool
+	}{
+		{
+			name: "full",
+			data: `{"user":"0xf1","vaultEquity":"10.5","pnl":"-1","allTimePnl":"2","daysFollowing":3,"vaultEntryTime":1734000000000,"lockupUntil":1734086400000}`,
+			want: hlstats.VaultFollower{
+				User:          "0xf1",
+				VaultEquity:   10.5,
+				PnL:           -1,
+				AllTimePnL:    2,
+				DaysFollowing: 3,
+				EntryTime:     time.UnixMilli(1734000000000).UTC(),
+				LockupUntil:   time.UnixMilli(1734086400000).UTC(),
+			},
+		},
+		{
+			name: "no lockup",
+			data: `{"user":"Leader","vaultEquity":"1","lockupUntil":0}`,
+			want: hlstats.VaultFollower{User: "Leader", VaultEquity: 1},
+		},
+		{
+			name: "invalid numbers are zero",
+			data: `{"user":"0xf2","vaultEquity":"n/a"}`,
+			want: hlstats.VaultFollower{User: "0xf2"},
+		},
+		{name: "numeric equity", data: `{"vaultEquity":10.5}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.VaultFollower
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultRelationshipUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.VaultRelationship
+		wantErr bool
+	}{
+		{
+			name: "normal",
+			data: `{"type":"normal"}`,
+			want: hlstats.VaultRelationship{Type: hlstats.RelationshipNormal},
+		},
+		{
+			name: "parent",
+			data: `{"type":"parent","data":{"childAddresses":["0xa1","0xe5"]}}`,
+			want: hlstats.VaultRelationship{Type: hlstats.RelationshipParent, ChildAddresses: []string{"0xa1", "0xe5"}},
+		},
+		{
+			name: "child",
+			data: `{"type":"child","data":{"parentAddress":"0xdf"}}`,
+			want: hlstats.VaultRelationship{Type: hlstats.RelationshipChild, ParentAddress: "0xdf"},
+		},
+		{name: "invalid", data: `"child"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.VaultRelationship
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
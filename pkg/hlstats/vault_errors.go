@@ -0,0 +1,75 @@
+package hlstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// VaultFetchError describes a vault whose volume could not be fetched.
+type VaultFetchError struct {
+	Address    string `json:"address"`
+	Name       string `json:"name"`
+	Attempts   int    `json:"attempts"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Err        error  `json:"-"`
+}
+
+func (e *VaultFetchError) Error() string {
+	return fmt.Sprintf("vault %s: %v (attempts: %d)", e.Address, e.Err, e.Attempts)
+}
+
+func (e *VaultFetchError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON includes the error message, which is otherwise dropped by encoding/json.
+func (e *VaultFetchError) MarshalJSON() ([]byte, error) {
+	type alias VaultFetchError
+	return json.Marshal(struct {
+		*alias
+		Error string `json:"error"`
+	}{
+		alias: (*alias)(e),
+		Error: fmt.Sprint(e.Err),
+	})
+}
+
+// VaultFetchErrors is a list of vault fetch failures.
+type VaultFetchErrors []*VaultFetchError
+
+// PartialFetchError is returned alongside the successful results when some vaults could not be fetched.
+type PartialFetchError struct {
+	Failures VaultFetchErrors
+	Total    int
+}
+
+func (e *PartialFetchError) Error() string {
+	addresses := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		addresses = append(addresses, f.Address)
+	}
+
+	return fmt.Sprintf("failed to fetch %d/%d vaults: %s", len(e.Failures), e.Total, strings.Join(addresses, ", "))
+}
+
+func (e *PartialFetchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f)
+	}
+
+	return errs
+}
+
+// AsPartialFetchError returns the PartialFetchError wrapped in err, if any.
+func AsPartialFetchError(err error) (*PartialFetchError, bool) {
+	var partial *PartialFetchError
+	if errors.As(err, &partial) {
+		return partial, true
+	}
+
+	return nil, false
+}
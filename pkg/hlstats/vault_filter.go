@@ -0,0 +1,112 @@
+package hlstats
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// VaultPredicate reports whether a vault of the listing is selected.
+type VaultPredicate func(v Vault) bool
+
+// Filter returns the vaults matching all predicates.
+func (data Vaults) Filter(predicates ...VaultPredicate) Vaults {
+	var filtered Vaults
+	for _, vault := range data {
+		if matchesAll(vault, predicates) {
+			filtered = append(filtered, vault)
+		}
+	}
+
+	return filtered
+}
+
+func matchesAll(vault Vault, predicates []VaultPredicate) bool {
+	for _, predicate := range predicates {
+		if !predicate(vault) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OpenOnly selects vaults that are not closed.
+func OpenOnly() VaultPredicate {
+	return func(v Vault) bool {
+		return !v.Data.Closed
+	}
+}
+
+// MinTVL selects vaults with a TVL of at least min.
+func MinTVL(min float64) VaultPredicate {
+	return func(v Vault) bool {
+		return v.Data.TVL >= min
+	}
+}
+
+// MaxTVL selects vaults with a TVL of at most max.
+func MaxTVL(max float64) VaultPredicate {
+	return func(v Vault) bool {
+		return v.Data.TVL <= max
+	}
+}
+
+// LeaderIn selects vaults led by one of the given addresses, compared case-insensitively.
+func LeaderIn(leaders ...string) VaultPredicate {
+	return func(v Vault) bool {
+		for _, leader := range leaders {
+			if strings.EqualFold(strings.TrimSpace(leader), v.Data.Leader) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AddressIn selects the vaults with one of the given addresses, compared case-insensitively.
+func AddressIn(addresses ...string) VaultPredicate {
+	return func(v Vault) bool {
+		for _, address := range addresses {
+			if strings.EqualFold(strings.TrimSpace(address), v.Data.Address) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NameMatches selects vaults whose name matches re.
+func NameMatches(re *regexp.Regexp) VaultPredicate {
+	return func(v Vault) bool {
+		return re.MatchString(v.Data.Name)
+	}
+}
+
+// CreatedAfter selects vaults created after t. Vaults without a create time are not selected.
+func CreatedAfter(t time.Time) VaultPredicate {
+	return func(v Vault) bool {
+		return !v.Data.CreateTime.IsZero() && v.Data.CreateTime.After(t)
+	}
+}
+
+// MinAPR selects vaults with an APR of at least min, as a fraction (0.1 is 10%).
+func MinAPR(min float64) VaultPredicate {
+	return func(v Vault) bool {
+		return v.APR >= min
+	}
+}
+
+// MaxAPR selects vaults with an APR of at most max, as a fraction (0.1 is 10%).
+func MaxAPR(max float64) VaultPredicate {
+	return func(v Vault) bool {
+		return v.APR <= max
+	}
+}
+
+// HLPOnly selects HLP vaults.
+func HLPOnly() VaultPredicate {
+	return func(v Vault) bool {
+		return v.IsHLP()
+	}
+}
@@ -0,0 +1,69 @@
+package hlstats_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestVaultsFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		predicates []hlstats.VaultPredicate
+		want       []string
+	}{
+		{name: "none", want: []string{"beta", "HLP Liquidator", "Alpha", "Retired", "HLP"}},
+		{name: "open", predicates: []hlstats.VaultPredicate{hlstats.OpenOnly()}, want: []string{"beta", "HLP Liquidator", "Alpha", "HLP"}},
+		{name: "min tvl", predicates: []hlstats.VaultPredicate{hlstats.MinTVL(200000.5)}, want: []string{"HLP Liquidator", "Alpha", "HLP"}},
+		{name: "max tvl", predicates: []hlstats.VaultPredicate{hlstats.MaxTVL(60000)}, want: []string{"beta", "Retired"}},
+		{
+			name:       "tvl range",
+			predicates: []hlstats.VaultPredicate{hlstats.MinTVL(10000), hlstats.MaxTVL(500000)},
+			want:       []string{"beta", "HLP Liquidator", "Alpha"},
+		},
+		{name: "leader", predicates: []hlstats.VaultPredicate{hlstats.LeaderIn(" 0X3 ", "0x5")}, want: []string{"Alpha", "Retired"}},
+		{name: "address", predicates: []hlstats.VaultPredicate{hlstats.AddressIn("0xE5", "0xff")}, want: []string{"HLP Liquidator"}},
+		{name: "no address", predicates: []hlstats.VaultPredicate{hlstats.AddressIn()}, want: nil},
+		{name: "name", predicates: []hlstats.VaultPredicate{hlstats.NameMatches(regexp.MustCompile(`(?i)^(alpha|beta)$`))}, want: []string{"beta", "Alpha"}},
+		{
+			name:       "created after",
+			predicates: []hlstats.VaultPredicate{hlstats.CreatedAfter(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))},
+			want:       []string{"Alpha"},
+		},
+		{name: "min apr", predicates: []hlstats.VaultPredicate{hlstats.MinAPR(0.1)}, want: []string{"Alpha", "HLP"}},
+		{name: "max apr", predicates: []hlstats.VaultPredicate{hlstats.MaxAPR(0.05)}, want: []string{"beta", "HLP Liquidator", "Retired"}},
+		{name: "hlp", predicates: []hlstats.VaultPredicate{hlstats.HLPOnly()}, want: []string{"HLP Liquidator", "HLP"}},
+		{
+			name:       "hlp with min tvl",
+			predicates: []hlstats.VaultPredicate{hlstats.HLPOnly(), hlstats.MinTVL(1000000)},
+			want:       []string{"HLP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testVaults().Filter(tt.predicates...)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(vaultNames(got), tt.want) {
+				t.Errorf("Filter() = %v, want %v", vaultNames(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultsFilterHelpers(t *testing.T) {
+	if got, want := vaultNames(testVaults().FilterOpenVaults()), []string{"beta", "HLP Liquidator", "Alpha", "HLP"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterOpenVaults() = %v, want %v", got, want)
+	}
+	if got, want := vaultNames(testVaults().FilterByStatus(true)), []string{"Retired"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterByStatus(true) = %v, want %v", got, want)
+	}
+	if got, want := vaultNames(testVaults().FilterByMinTVL(500000)), []string{"HLP Liquidator", "HLP"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterByMinTVL() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package hlstats
+
+import (
+	"sort"
+	"strings"
+)
+
+// leaderFollowerName is the user reported by vaultDetails for the leader's own deposit.
+const leaderFollowerName = "Leader"
+
+// VaultFollowers is the list of depositors of a vault.
+type VaultFollowers []VaultFollower
+
+// SortByEquity sorts the followers by vault equity, largest first.
+func (data VaultFollowers) SortByEquity() VaultFollowers {
+	result := make(VaultFollowers, len(data))
+	copy(result, data)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].VaultEquity > result[j].VaultEquity
+	})
+
+	return result
+}
+
+// TotalEquity returns the sum of the followers' vault equity.
+func (data VaultFollowers) TotalEquity() float64 {
+	var total float64
+	for _, f := range data {
+		total += f.VaultEquity
+	}
+
+	return total
+}
+
+// TopShare returns the share of the total equity held by the n largest followers, 0 if n is not
+// positive.
+func (data VaultFollowers) TopShare(n int) float64 {
+	total := data.TotalEquity()
+	if total <= 0 || n <= 0 {
+		return 0
+	}
+
+	sorted := data.SortByEquity()
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+
+	var top float64
+	for _, f := range sorted[:n] {
+		top += f.VaultEquity
+	}
+
+	return top / total
+}
+
+// HHI returns the Herfindahl-Hirschman index of the followers' equity shares, between 0 and 1.
+// It is 1 when a single follower holds all the equity and 1/n when n followers hold equal stakes.
+func (data VaultFollowers) HHI() float64 {
+	total := data.TotalEquity()
+	if total <= 0 {
+		return 0
+	}
+
+	var hhi float64
+	for _, f := range data {
+		share := f.VaultEquity / total
+		hhi += share * share
+	}
+
+	return hhi
+}
+
+// FollowerConcentration summarises how the equity of a vault is spread across its depositors.
+type FollowerConcentration struct {
+	Followers   int     `json:"followers"`
+	TotalEquity float64 `json:"totalEquity"`
+	TopN        int     `json:"topN"`
+	TopNShare   float64 `json:"topNShare"`
+	HHI         float64 `json:"hhi"`
+	// LeaderEquity is the leader's own stake in the vault and LeaderShare its share of the total equity.
+	LeaderEquity float64 `json:"leaderEquity"`
+	LeaderShare  float64 `json:"leaderShare"`
+}
+
+// LeaderStake returns the leader's own stake in the vault. The leader's entry in the followers list
+// is used when present; otherwise the stake is derived from the leader fraction.
+func (d VaultDetails) LeaderStake() float64 {
+	for _, f := range d.Followers {
+		if f.User == leaderFollowerName || strings.EqualFold(f.User, d.Leader) {
+			return f.VaultEquity
+		}
+	}
+
+	return d.LeaderFraction * d.Followers.TotalEquity()
+}
+
+// FollowerConcentration computes the concentration of the vault's equity, using the n largest
+// followers for the top-N share.
+func (d VaultDetails) FollowerConcentration(n int) FollowerConcentration {
+	followers := d.Followers
+	c := FollowerConcentration{
+		Followers:    len(followers),
+		TotalEquity:  followers.TotalEquity(),
+		TopN:         n,
+		TopNShare:    followers.TopShare(n),
+		HHI:          followers.HHI(),
+		LeaderEquity: d.LeaderStake(),
+	}
+	if c.TotalEquity > 0 {
+		c.LeaderShare = c.LeaderEquity / c.TotalEquity
+	}
+
+	return c
+}
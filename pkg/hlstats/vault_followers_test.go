@@ -0,0 +1,91 @@
+package hlstats_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestVaultFollowers(t *testing.T) {
+	followers := hlstats.VaultFollowers{
+		{User: "0xf1", VaultEquity: 10},
+		{User: "Leader", VaultEquity: 50},
+		{User: "0xf2", VaultEquity: 40},
+	}
+
+	var users []string
+	for _, f := range followers.SortByEquity() {
+		users = append(users, f.User)
+	}
+	if want := []string{"Leader", "0xf2", "0xf1"}; !reflect.DeepEqual(users, want) {
+		t.Errorf("SortByEquity() = %v, want %v", users, want)
+	}
+
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "TotalEquity", got: followers.TotalEquity(), want: 100},
+		{name: "TopShare(1)", got: followers.TopShare(1), want: 0.5},
+		{name: "TopShare(5)", got: followers.TopShare(5), want: 1},
+		{name: "TopShare(0)", got: followers.TopShare(0), want: 0},
+		{name: "TopShare(-1)", got: followers.TopShare(-1), want: 0},
+		{name: "HHI", got: followers.HHI(), want: 0.01 + 0.25 + 0.16},
+		{name: "empty TopShare", got: hlstats.VaultFollowers{}.TopShare(3), want: 0},
+		{name: "empty HHI", got: hlstats.VaultFollowers{}.HHI(), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !approxEqual(tt.got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultDetailsFollowerConcentration(t *testing.T) {
+	tests := []struct {
+		name    string
+		details hlstats.VaultDetails
+		want    hlstats.FollowerConcentration
+	}{
+		{
+			name: "leader entry",
+			details: hlstats.VaultDetails{
+				Leader:         "0x3",
+				LeaderFraction: 0.9,
+				Followers:      hlstats.VaultFollowers{{User: "Leader", VaultEquity: 25}, {User: "0xf1", VaultEquity: 75}},
+			},
+			want: hlstats.FollowerConcentration{Followers: 2, TotalEquity: 100, TopN: 1, TopNShare: 0.75, HHI: 0.625, LeaderEquity: 25, LeaderShare: 0.25},
+		},
+		{
+			name: "leader fraction",
+			details: hlstats.VaultDetails{
+				Leader:         "0x3",
+				LeaderFraction: 0.1,
+				Followers:      hlstats.VaultFollowers{{User: "0xf1", VaultEquity: 40}, {User: "0xf2", VaultEquity: 60}},
+			},
+			want: hlstats.FollowerConcentration{Followers: 2, TotalEquity: 100, TopN: 1, TopNShare: 0.6, HHI: 0.52, LeaderEquity: 10, LeaderShare: 0.1},
+		},
+		{
+			name:    "no followers",
+			details: hlstats.VaultDetails{LeaderFraction: 0.5},
+			want:    hlstats.FollowerConcentration{TopN: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.details.FollowerConcentration(1)
+			if got.Followers != tt.want.Followers || got.TopN != tt.want.TopN ||
+				!approxEqual(got.TotalEquity, tt.want.TotalEquity) ||
+				!approxEqual(got.TopNShare, tt.want.TopNShare) ||
+				!approxEqual(got.HHI, tt.want.HHI) ||
+				!approxEqual(got.LeaderEquity, tt.want.LeaderEquity) ||
+				!approxEqual(got.LeaderShare, tt.want.LeaderShare) {
+				t.Errorf("FollowerConcentration() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
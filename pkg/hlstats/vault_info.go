@@ -0,0 +1,196 @@
+package hlstats
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// VaultSummary is the summary of a vault in the vaults listing.
+type VaultSummary struct {
+	Name    string  `json:"name"`
+	Address string  `json:"vaultAddress"`
+	Leader  string  `json:"leader"`
+	TVL     float64 `json:"tvl,omitempty"`
+	Closed  bool    `json:"isClosed"`
+	// CreateTime is the vault's creation time; it is zero if the listing does not report it.
+	CreateTime   time.Time         `json:"createTime"`
+	Relationship VaultRelationship `json:"relationship"`
+}
+
+func (item *VaultSummary) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Name         string            `json:"name"`
+		Address      string            `json:"vaultAddress"`
+		Leader       string            `json:"leader"`
+		TVL          string            `json:"tvl"`
+		Closed       bool              `json:"isClosed"`
+		CreateTime   int64             `json:"createTimeMillis"`
+		Relationship VaultRelationship `json:"relationship"`
+	}
+
+	err := json.Unmarshal(data, &tmp)
+	if err != nil {
+		return err
+	}
+	item.Name = tmp.Name
+	item.Address = tmp.Address
+	item.Leader = tmp.Leader
+	item.TVL, _ = strconv.ParseFloat(tmp.TVL, 64)
+	item.Closed = tmp.Closed
+	item.CreateTime = unixMilli(tmp.CreateTime)
+	item.Relationship = tmp.Relationship
+
+	return nil
+}
+
+// VaultPnLSeries holds the PnL series of a vault per period, as reported by the vaults listing.
+// The listing reports values only, without timestamps.
+type VaultPnLSeries struct {
+	Day     []float64 `json:"day"`
+	Week    []float64 `json:"week"`
+	Month   []float64 `json:"month"`
+	AllTime []float64 `json:"allTime"`
+}
+
+// The listing encodes the series as a list of [period, values] pairs.
+func (s *VaultPnLSeries) UnmarshalJSON(data []byte) error {
+	var tmp [][2]json.RawMessage
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	for _, item := range tmp {
+		var name string
+		if err := json.Unmarshal(item[0], &name); err != nil {
+			return errors.Wrap(err, "invalid pnl period name")
+		}
+
+		var raw []json.RawMessage
+		if err := json.Unmarshal(item[1], &raw); err != nil {
+			return errors.Wrapf(err, "invalid pnl series %q", name)
+		}
+		values := make([]float64, 0, len(raw))
+		for _, r := range raw {
+			value, err := parseFloatField(r)
+			if err != nil {
+				return errors.Wrapf(err, "invalid pnl value in series %q", name)
+			}
+			values = append(values, value)
+		}
+
+		switch name {
+		case "day":
+			s.Day = values
+		case "week":
+			s.Week = values
+		case "month":
+			s.Month = values
+		case "allTime":
+			s.AllTime = values
+		}
+	}
+
+	return nil
+}
+
+// Vault is an entry of the vaults listing.
+type Vault struct {
+	Data VaultSummary   `json:"summary"`
+	APR  float64        `json:"apr"`
+	PnLs VaultPnLSeries `json:"pnls"`
+	// HLP is set by the client when the vault is led by the HLP leader of its network or is a
+	// child of its HLP parent vault.
+	HLP bool `json:"isHLP"`
+}
+
+// Age returns how long ago the vault was created relative to now, or 0 if the create time is unknown.
+func (v *Vault) Age(now time.Time) time.Duration {
+	if v.Data.CreateTime.IsZero() {
+		return 0
+	}
+
+	return now.Sub(v.Data.CreateTime)
+}
+
+// IsHLP reports whether the vault is an HLP strategy vault.
+func (v *Vault) IsHLP() bool {
+	return v.HLP
+}
+
+// Vaults is the vaults listing.
+type Vaults []Vault
+
+// FilterByStatus returns the vaults whose closed status matches closed.
+func (data Vaults) FilterByStatus(closed bool) Vaults {
+	return data.Filter(func(v Vault) bool { return v.Data.Closed == closed })
+}
+
+// FilterOpenVaults returns the vaults that are not closed.
+func (data Vaults) FilterOpenVaults() Vaults {
+	return data.Filter(OpenOnly())
+}
+
+// FilterByMinTVL returns the vaults with a TVL of at least minTVL.
+func (data Vaults) FilterByMinTVL(minTVL float64) Vaults {
+	return data.Filter(MinTVL(minTVL))
+}
+
+// VaultSortFields are the fields vaults can be sorted by with Vaults.SortBy. Numbers and hlp sort
+// descending by default, text ascending; age sorts oldest first, with an unknown create time
+// counting as the youngest.
+var VaultSortFields = SortFields[Vault]{
+	"hlp":     boolField(func(v Vault) bool { return v.IsHLP() }),
+	"name":    textField(func(v Vault) string { return v.Data.Name }),
+	"address": textField(func(v Vault) string { return v.Data.Address }),
+	"leader":  textField(func(v Vault) string { return v.Data.Leader }),
+	"tvl":     numberField(func(v Vault) float64 { return v.Data.TVL }),
+	"apr":     numberField(func(v Vault) float64 { return v.APR }),
+	"age": {
+		Compare: func(a, b Vault) int {
+			switch {
+			case a.Data.CreateTime.IsZero() || b.Data.CreateTime.IsZero():
+				return compareBool(!a.Data.CreateTime.IsZero(), !b.Data.CreateTime.IsZero())
+			default:
+				return b.Data.CreateTime.Compare(a.Data.CreateTime)
+			}
+		},
+		Desc: true,
+	},
+}
+
+// SortBy returns the vaults stably sorted by keys (see VaultSortFields).
+func (data Vaults) SortBy(keys SortKeys) (Vaults, error) {
+	return VaultSortFields.Sort(data, keys)
+}
+
+// SortWithHLPPriority sorts HLP vaults first, then by TVL within each group.
+func (data Vaults) SortWithHLPPriority(ascending bool) Vaults {
+	result, _ := data.SortWithHLPPriorityBy("tvl", ascending)
+	return result
+}
+
+// SortWithHLPPriorityBy sorts HLP vaults first, then by the given field (see VaultSortFields) within
+// each group.
+func (data Vaults) SortWithHLPPriorityBy(field string, ascending bool) (Vaults, error) {
+	order := SortDesc
+	if ascending {
+		order = SortAsc
+	}
+
+	return data.SortBy(SortKeys{{Field: "hlp", Order: SortDesc}, {Field: strings.ToLower(field), Order: order}})
+}
+
+// SortByTVL sorts the vaults by TVL.
+func (data Vaults) SortByTVL(ascending bool) Vaults {
+	order := SortDesc
+	if ascending {
+		order = SortAsc
+	}
+	result, _ := data.SortBy(SortKeys{{Field: "tvl", Order: order}})
+
+	return result
+}
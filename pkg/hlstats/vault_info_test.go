@@ -0,0 +1,185 @@
+package hlstats_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestVaultSummaryUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.VaultSummary
+		wantErr bool
+	}{
+		{
+			name: "full",
+			data: `{"name":"Alpha","vaultAddress":"0xb2","leader":"0x3","tvl":"200000.5","isClosed":false,"createTimeMillis":1750000000000,
+				"relationship":{"type":"child","data":{"parentAddress":"0xdf"}}}`,
+			want: hlstats.VaultSummary{
+				Name:         "Alpha",
+				Address:      "0xb2",
+				Leader:       "0x3",
+				TVL:          200000.5,
+				CreateTime:   time.UnixMilli(1750000000000).UTC(),
+				Relationship: hlstats.VaultRelationship{Type: hlstats.RelationshipChild, ParentAddress: "0xdf"},
+			},
+		},
+		{
+			name: "closed without create time",
+			data: `{"name":"Retired","vaultAddress":"0xd4","leader":"0x5","tvl":"0.0","isClosed":true}`,
+			want: hlstats.VaultSummary{Name: "Retired", Address: "0xd4", Leader: "0x5", Closed: true},
+		},
+		{
+			name: "invalid tvl is zero",
+			data: `{"name":"Odd","tvl":"-"}`,
+			want: hlstats.VaultSummary{Name: "Odd"},
+		},
+		{name: "numeric tvl", data: `{"tvl":1}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.VaultSummary
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultPnLSeriesUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.VaultPnLSeries
+		wantErr bool
+	}{
+		{
+			name: "all periods",
+			data: `[["day",["0.0","1.5"]],["week",["0.0","2.5",3]],["month",[]],["allTime",["0.0","10.0"]]]`,
+			want: hlstats.VaultPnLSeries{
+				Day:     []float64{0, 1.5},
+				Week:    []float64{0, 2.5, 3},
+				Month:   []float64{},
+				AllTime: []float64{0, 10},
+			},
+		},
+		{
+			name: "unknown periods are ignored",
+			data: `[["hour",["1"]]]`,
+			want: hlstats.VaultPnLSeries{},
+		},
+		{name: "invalid value", data: `[["day",["x"]]]`, wantErr: true},
+		{name: "invalid series", data: `[["day","1"]]`, wantErr: true},
+		{name: "invalid period name", data: `[[1,[]]]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.VaultPnLSeries
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testVaults is a small vaults listing covering HLP, open, closed and undated vaults.
+func testVaults() hlstats.Vaults {
+	return hlstats.Vaults{
+		{Data: hlstats.VaultSummary{Name: "beta", Address: "0xc3", Leader: "0x4", TVL: 60000}, APR: 0.05},
+		{Data: hlstats.VaultSummary{Name: "HLP Liquidator", Address: "0xe5", Leader: "0xdf", TVL: 500000}, HLP: true},
+		{Data: hlstats.VaultSummary{Name: "Alpha", Address: "0xb2", Leader: "0x3", TVL: 200000.5, CreateTime: time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)}, APR: 0.35},
+		{Data: hlstats.VaultSummary{Name: "Retired", Address: "0xd4", Leader: "0x5", Closed: true, CreateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{Data: hlstats.VaultSummary{Name: "HLP", Address: "0xdf", Leader: "0x6", TVL: 1500000}, HLP: true, APR: 0.1},
+	}
+}
+
+// vaultNames returns the names of the vaults, in order.
+func vaultNames(vaults hlstats.Vaults) []string {
+	names := make([]string, 0, len(vaults))
+	for _, vault := range vaults {
+		names = append(names, vault.Data.Name)
+	}
+
+	return names
+}
+
+func TestVaultsSortBy(t *testing.T) {
+	tests := []struct {
+		keys    string
+		want    []string
+		wantErr bool
+	}{
+		{keys: "tvl", want: []string{"HLP", "HLP Liquidator", "Alpha", "beta", "Retired"}},
+		{keys: "tvl:asc", want: []string{"Retired", "beta", "Alpha", "HLP Liquidator", "HLP"}},
+		{keys: "name", want: []string{"Alpha", "beta", "HLP", "HLP Liquidator", "Retired"}},
+		{keys: "apr,name:desc", want: []string{"Alpha", "HLP", "beta", "Retired", "HLP Liquidator"}},
+		{keys: "hlp,tvl:asc", want: []string{"HLP Liquidator", "HLP", "Retired", "beta", "Alpha"}},
+		{keys: "age", want: []string{"Retired", "Alpha", "beta", "HLP Liquidator", "HLP"}},
+		{keys: "age:asc", want: []string{"beta", "HLP Liquidator", "HLP", "Alpha", "Retired"}},
+		{keys: "leader", want: []string{"Alpha", "beta", "Retired", "HLP", "HLP Liquidator"}},
+		{keys: "", want: []string{"beta", "HLP Liquidator", "Alpha", "Retired", "HLP"}},
+		{keys: "volume", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keys, func(t *testing.T) {
+			keys, err := hlstats.ParseSortKeys(tt.keys)
+			if err != nil {
+				t.Fatalf("ParseSortKeys() error = %v", err)
+			}
+
+			got, err := testVaults().SortBy(keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SortBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(vaultNames(got), tt.want) {
+				t.Errorf("SortBy() = %v, want %v", vaultNames(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultsSortWithHLPPriority(t *testing.T) {
+	got := vaultNames(testVaults().SortWithHLPPriority(false))
+	want := []string{"HLP", "HLP Liquidator", "Alpha", "beta", "Retired"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortWithHLPPriority(false) = %v, want %v", got, want)
+	}
+
+	got = vaultNames(testVaults().SortByTVL(true))
+	want = []string{"Retired", "beta", "Alpha", "HLP Liquidator", "HLP"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortByTVL(true) = %v, want %v", got, want)
+	}
+
+	if _, err := testVaults().SortWithHLPPriorityBy("followers", false); err == nil {
+		t.Errorf("SortWithHLPPriorityBy(%q) error = nil", "followers")
+	}
+}
+
+func TestVaultAge(t *testing.T) {
+	now := time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC)
+	vaults := testVaults()
+
+	if got := vaults[2].Age(now); got != 10*24*time.Hour {
+		t.Errorf("Age() = %v, want %v", got, 10*24*time.Hour)
+	}
+	if got := vaults[0].Age(now); got != 0 {
+		t.Errorf("Age() of a vault without create time = %v, want 0", got)
+	}
+}
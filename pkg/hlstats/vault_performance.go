@@ -0,0 +1,326 @@
+package hlstats
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Performance metrics that vault performances can be ranked by.
+const (
+	MetricReturn      = "return"
+	MetricDrawdown    = "drawdown"
+	MetricVolatility  = "volatility"
+	MetricSharpe      = "sharpe"
+	MetricSortino     = "sortino"
+	MetricPnLToVolume = "pnl-volume"
+)
+
+// PerformanceMetrics lists the supported performance metrics.
+var PerformanceMetrics = []string{
+	MetricReturn, MetricDrawdown, MetricVolatility, MetricSharpe, MetricSortino, MetricPnLToVolume,
+}
+
+// PerformancePeriods lists the periods performance is computed for, as accepted by
+// VaultPerformances.SortByMetric.
+var PerformancePeriods = []string{"day", "week", "month", "all-time"}
+
+const year = 365 * 24 * time.Hour
+
+const (
+	// minRatioReturns is the number of returns between samples below which the Sharpe and Sortino
+	// ratios are undefined.
+	minRatioReturns = 5
+	// minRatioVolatility is the annualised volatility below which the Sharpe and Sortino ratios are
+	// undefined: the returns of a nearly flat series would be divided by noise.
+	minRatioVolatility = 0.01
+)
+
+// Ratio is a risk-adjusted return. It is NaN if it is undefined, and +Inf for a Sortino ratio of
+// returns without losses. JSON encodes NaN as null and +Inf as the string "+Inf".
+type Ratio float64
+
+// Undefined reports whether the ratio is undefined.
+func (r Ratio) Undefined() bool {
+	return math.IsNaN(float64(r))
+}
+
+func (r Ratio) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Undefined():
+		return []byte("null"), nil
+	case math.IsInf(float64(r), 1):
+		return []byte(`"+Inf"`), nil
+	default:
+		return json.Marshal(float64(r))
+	}
+}
+
+func (r *Ratio) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null":
+		*r = Ratio(math.NaN())
+		return nil
+	case `"+Inf"`:
+		*r = Ratio(math.Inf(1))
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return errors.Wrap(err, "invalid ratio")
+	}
+	*r = Ratio(value)
+
+	return nil
+}
+
+// PeriodPerformance holds the performance of a vault over one portfolio period.
+// Ratios are fractions (0.05 is 5%); Sharpe and Sortino assume a zero risk-free rate.
+type PeriodPerformance struct {
+	// Return is the time-weighted return, which excludes the effect of deposits and withdrawals.
+	Return float64 `json:"return"`
+	// MaxDrawdown is the largest peak-to-trough decline of the time-weighted return, as a positive fraction.
+	MaxDrawdown float64 `json:"maxDrawdown"`
+	// Volatility is the annualised standard deviation of the returns between samples.
+	Volatility float64 `json:"volatility"`
+	// Sharpe and Sortino are undefined if there are too few returns or the volatility is too low to
+	// be meaningful. Sortino is +Inf if no return is a loss.
+	Sharpe      Ratio   `json:"sharpe"`
+	Sortino     Ratio   `json:"sortino"`
+	PnL         float64 `json:"pnl"`
+	Volume      float64 `json:"volume"`
+	PnLToVolume float64 `json:"pnlToVolume"`
+	// Samples is the number of account value samples the metrics are based on.
+	Samples int `json:"samples"`
+}
+
+// Metric returns the named metric (see PerformanceMetrics).
+func (p PeriodPerformance) Metric(metric string) (float64, error) {
+	switch strings.ToLower(metric) {
+	case MetricReturn:
+		return p.Return, nil
+	case MetricDrawdown:
+		return p.MaxDrawdown, nil
+	case MetricVolatility:
+		return p.Volatility, nil
+	case MetricSharpe:
+		return float64(p.Sharpe), nil
+	case MetricSortino:
+		return float64(p.Sortino), nil
+	case MetricPnLToVolume:
+		return p.PnLToVolume, nil
+	default:
+		return 0, errors.Errorf("invalid metric '%s'. Valid options: %s", metric, strings.Join(PerformanceMetrics, ", "))
+	}
+}
+
+// ComputePerformance computes the performance of a portfolio period. The return between two
+// consecutive samples is the PnL earned in between divided by the account value at the start, so
+// deposits and withdrawals, which move the account value but not the PnL, do not count as returns.
+func ComputePerformance(period PortfolioPeriod) PeriodPerformance {
+	perf := PeriodPerformance{
+		Sharpe:  Ratio(math.NaN()),
+		Sortino: Ratio(math.NaN()),
+		PnL:     period.PnLHistory.Last(),
+		Volume:  period.Volume,
+		Samples: len(period.AccountValueHistory),
+	}
+	if perf.Volume != 0 {
+		perf.PnLToVolume = perf.PnL / perf.Volume
+	}
+
+	pnl := make(map[int64]float64, len(period.PnLHistory))
+	for _, point := range period.PnLHistory {
+		pnl[point.Time.UnixMilli()] = point.Value
+	}
+
+	var returns []float64
+	var elapsed time.Duration
+	growth, peak := 1.0, 1.0
+	for i := 1; i < len(period.AccountValueHistory); i++ {
+		prev, cur := period.AccountValueHistory[i-1], period.AccountValueHistory[i]
+		prevPnL, okPrev := pnl[prev.Time.UnixMilli()]
+		curPnL, okCur := pnl[cur.Time.UnixMilli()]
+		if !okPrev || !okCur || prev.Value <= 0 {
+			continue
+		}
+
+		r := (curPnL - prevPnL) / prev.Value
+		returns = append(returns, r)
+		elapsed += cur.Time.Sub(prev.Time)
+
+		growth *= 1 + r
+		peak = math.Max(peak, growth)
+		perf.MaxDrawdown = math.Max(perf.MaxDrawdown, (peak-growth)/peak)
+	}
+	perf.Return = growth - 1
+
+	if len(returns) < 2 || elapsed <= 0 {
+		return perf
+	}
+
+	// Samples are not evenly spaced; annualise using the average interval between them
+	periodsPerYear := float64(year) / (float64(elapsed) / float64(len(returns)))
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance, downside float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+		if r < 0 {
+			downside += r * r
+		}
+	}
+	variance /= float64(len(returns) - 1)
+	downside /= float64(len(returns))
+
+	annualMean := mean * periodsPerYear
+	perf.Volatility = math.Sqrt(variance * periodsPerYear)
+	if len(returns) < minRatioReturns || perf.Volatility < minRatioVolatility {
+		return perf
+	}
+
+	perf.Sharpe = Ratio(annualMean / perf.Volatility)
+	if downside == 0 {
+		// No return is a loss
+		perf.Sortino = Ratio(math.Inf(1))
+	} else {
+		perf.Sortino = Ratio(annualMean / math.Sqrt(downside*periodsPerYear))
+	}
+
+	return perf
+}
+
+// VaultPerformance is the performance of a vault per period together with its listing details.
+type VaultPerformance struct {
+	Address string            `json:"address"`
+	Name    string            `json:"name"`
+	TVL     float64           `json:"tvl"`
+	IsHLP   bool              `json:"isHLP"`
+	Day     PeriodPerformance `json:"day"`
+	Week    PeriodPerformance `json:"week"`
+	Month   PeriodPerformance `json:"month"`
+	AllTime PeriodPerformance `json:"allTime"`
+}
+
+// NewVaultPerformance computes the performance of every period of details.
+func NewVaultPerformance(vault Vault, details VaultDetails) VaultPerformance {
+	return VaultPerformance{
+		Address: vault.Data.Address,
+		Name:    vault.Data.Name,
+		TVL:     vault.Data.TVL,
+		IsHLP:   vault.IsHLP(),
+		Day:     ComputePerformance(details.Portfolio.Day),
+		Week:    ComputePerformance(details.Portfolio.Week),
+		Month:   ComputePerformance(details.Portfolio.Month),
+		AllTime: ComputePerformance(details.Portfolio.AllTime),
+	}
+}
+
+// Period returns the performance of the named period (see PerformancePeriods).
+func (v VaultPerformance) Period(period string) (PeriodPerformance, error) {
+	switch strings.ToLower(period) {
+	case "day":
+		return v.Day, nil
+	case "week":
+		return v.Week, nil
+	case "month":
+		return v.Month, nil
+	case "all-time", "alltime":
+		return v.AllTime, nil
+	default:
+		return PeriodPerformance{}, errors.Errorf("invalid period '%s'. Valid options: %s", period, strings.Join(PerformancePeriods, ", "))
+	}
+}
+
+// VaultPerformances is a list of vault performances.
+type VaultPerformances []VaultPerformance
+
+// VaultPerformanceSortFields returns the fields vault performances can be sorted by, with the
+// metrics (see PerformanceMetrics) taken from the given period. Metrics sort best first by default:
+// ascending for drawdown and volatility, descending otherwise. Undefined ratios sort last in either
+// direction. The fields hlp, name, address and tvl are also accepted.
+func VaultPerformanceSortFields(period string) (SortFields[VaultPerformance], error) {
+	if _, err := (VaultPerformance{}).Period(period); err != nil {
+		return nil, err
+	}
+
+	fields := SortFields[VaultPerformance]{
+		"hlp":     boolField(func(v VaultPerformance) bool { return v.IsHLP }),
+		"name":    textField(func(v VaultPerformance) string { return v.Name }),
+		"address": textField(func(v VaultPerformance) string { return v.Address }),
+		"tvl":     numberField(func(v VaultPerformance) float64 { return v.TVL }),
+	}
+	for _, metric := range PerformanceMetrics {
+		field := numberField(func(v VaultPerformance) float64 {
+			perf, _ := v.Period(period)
+			m, _ := perf.Metric(metric)
+			return m
+		})
+		field.Desc = metric != MetricDrawdown && metric != MetricVolatility
+		field.Undefined = func(v VaultPerformance) bool {
+			perf, _ := v.Period(period)
+			m, _ := perf.Metric(metric)
+			return math.IsNaN(m)
+		}
+		fields[metric] = field
+	}
+
+	return fields, nil
+}
+
+// SortBy returns the vaults stably sorted by keys, with metrics of the given period (see
+// VaultPerformanceSortFields).
+func (data VaultPerformances) SortBy(period string, keys SortKeys) (VaultPerformances, error) {
+	fields, err := VaultPerformanceSortFields(period)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields.Sort(data, keys)
+}
+
+// SortByMetric ranks the vaults by a metric of a period, best first: ascending for drawdown and
+// volatility, descending otherwise.
+func (data VaultPerformances) SortByMetric(period, metric string) (VaultPerformances, error) {
+	metric = strings.ToLower(metric)
+	if _, err := (PeriodPerformance{}).Metric(metric); err != nil {
+		return nil, err
+	}
+
+	return data.SortBy(period, SortKeys{{Field: metric}})
+}
+
+// FetchAllVaultPerformancesConcurrent fetches the details of the vaults matching filters (by
+// default, open vaults with some TVL) using a pool of workers and computes their performance.
+// The page and errors are handled as by FetchAllVaultVolumesConcurrent.
+func (c *Client) FetchAllVaultPerformancesConcurrent(ctx context.Context, hlpOnly bool, page Page, workers int, filters ...VaultPredicate) (VaultPerformances, error) {
+	vaults, err := c.selectVaults(ctx, hlpOnly, page, filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(vaults) == 0 {
+		return VaultPerformances{}, nil
+	}
+
+	result, err := fetchVaultsConcurrent(ctx, c, vaults, workers, "vault performance",
+		func(ctx context.Context, vault Vault) (VaultPerformance, error) {
+			details, err := c.FetchVaultDetails(ctx, vault.Data.Address)
+			if err != nil {
+				return VaultPerformance{}, err
+			}
+
+			return NewVaultPerformance(vault, details), nil
+		})
+
+	return result, err
+}
@@ -0,0 +1,270 @@
+package hlstats_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// series returns a portfolio period with one account value and PnL sample per day.
+func series(volume float64, accountValues, pnls []float64) hlstats.PortfolioPeriod {
+	period := hlstats.PortfolioPeriod{Volume: volume}
+	for i := range accountValues {
+		at := time.Date(2026, 10, 1+i, 0, 0, 0, 0, time.UTC)
+		period.AccountValueHistory = append(period.AccountValueHistory, hlstats.HistoryPoint{Time: at, Value: accountValues[i]})
+		period.PnLHistory = append(period.PnLHistory, hlstats.HistoryPoint{Time: at, Value: pnls[i]})
+	}
+
+	return period
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestComputePerformance(t *testing.T) {
+	tests := []struct {
+		name   string
+		period hlstats.PortfolioPeriod
+		want   hlstats.PeriodPerformance
+	}{
+		{
+			name:   "empty",
+			period: hlstats.PortfolioPeriod{},
+			want:   hlstats.PeriodPerformance{},
+		},
+		{
+			name:   "steady gains",
+			period: series(2000, []float64{1000, 1010, 1020}, []float64{0, 10, 20}),
+			want: hlstats.PeriodPerformance{
+				Return:      0.02,
+				Volatility:  math.Sqrt(math.Pow(0.01-10.0/1010, 2) / 2 * 365),
+				PnL:         20,
+				Volume:      2000,
+				PnLToVolume: 0.01,
+				Samples:     3,
+			},
+		},
+		{
+			name:   "drawdown",
+			period: series(0, []float64{1000, 1100, 990}, []float64{0, 100, -10}),
+			want: hlstats.PeriodPerformance{
+				Return:      -0.01,
+				MaxDrawdown: 0.1,
+				Volatility:  math.Sqrt(0.02 * 365),
+				PnL:         -10,
+				Samples:     3,
+			},
+		},
+		{
+			name:   "deposits are not returns",
+			period: series(0, []float64{1000, 2000}, []float64{0, 0}),
+			want:   hlstats.PeriodPerformance{Samples: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hlstats.ComputePerformance(tt.period)
+
+			if got.Samples != tt.want.Samples {
+				t.Errorf("Samples = %d, want %d", got.Samples, tt.want.Samples)
+			}
+			for _, metric := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"Return", got.Return, tt.want.Return},
+				{"MaxDrawdown", got.MaxDrawdown, tt.want.MaxDrawdown},
+				{"Volatility", got.Volatility, tt.want.Volatility},
+				{"PnL", got.PnL, tt.want.PnL},
+				{"Volume", got.Volume, tt.want.Volume},
+				{"PnLToVolume", got.PnLToVolume, tt.want.PnLToVolume},
+			} {
+				if !approxEqual(metric.got, metric.want) {
+					t.Errorf("%s = %v, want %v", metric.name, metric.got, metric.want)
+				}
+			}
+		})
+	}
+}
+
+// returnSeries returns a portfolio period without deposits or withdrawals, starting at an account
+// value of 100, with the given returns between daily samples.
+func returnSeries(returns ...float64) hlstats.PortfolioPeriod {
+	accountValues, pnls := []float64{100}, []float64{0}
+	for _, r := range returns {
+		last := accountValues[len(accountValues)-1]
+		accountValues = append(accountValues, last*(1+r))
+		pnls = append(pnls, pnls[len(pnls)-1]+last*r)
+	}
+
+	return series(0, accountValues, pnls)
+}
+
+func TestComputePerformanceRatios(t *testing.T) {
+	tests := []struct {
+		name   string
+		period hlstats.PortfolioPeriod
+		check  func(t *testing.T, perf hlstats.PeriodPerformance)
+	}{
+		{
+			name:   "gains and losses",
+			period: returnSeries(0.01, 0.03, -0.01, 0.02, -0.005, 0.01),
+			check: func(t *testing.T, perf hlstats.PeriodPerformance) {
+				if perf.Sharpe.Undefined() || perf.Sortino.Undefined() || !(perf.Sharpe > 0 && perf.Sortino > perf.Sharpe) || math.IsInf(float64(perf.Sortino), 1) {
+					t.Errorf("Sharpe = %v, Sortino = %v; want 0 < Sharpe < Sortino < +Inf", perf.Sharpe, perf.Sortino)
+				}
+				if !approxEqual(perf.MaxDrawdown, 0.01) {
+					t.Errorf("MaxDrawdown = %v, want 0.01", perf.MaxDrawdown)
+				}
+			},
+		},
+		{
+			name:   "no losses",
+			period: returnSeries(0.01, 0.03, 0, 0.02, 0.005, 0.01),
+			check: func(t *testing.T, perf hlstats.PeriodPerformance) {
+				if perf.Sharpe.Undefined() || perf.Sharpe <= 0 || math.IsInf(float64(perf.Sharpe), 0) {
+					t.Errorf("Sharpe = %v, want a positive ratio", perf.Sharpe)
+				}
+				if !math.IsInf(float64(perf.Sortino), 1) {
+					t.Errorf("Sortino = %v, want +Inf", perf.Sortino)
+				}
+			},
+		},
+		{
+			name:   "too few returns",
+			period: returnSeries(0.01, 0.03, -0.01),
+			check: func(t *testing.T, perf hlstats.PeriodPerformance) {
+				if !perf.Sharpe.Undefined() || !perf.Sortino.Undefined() {
+					t.Errorf("Sharpe = %v, Sortino = %v; want both undefined", perf.Sharpe, perf.Sortino)
+				}
+			},
+		},
+		{
+			name:   "flat",
+			period: returnSeries(0.0001, 0.00011, 0.0001, 0.00011, 0.0001, 0.00011),
+			check: func(t *testing.T, perf hlstats.PeriodPerformance) {
+				if !perf.Sharpe.Undefined() || !perf.Sortino.Undefined() {
+					t.Errorf("Sharpe = %v, Sortino = %v with volatility %v; want both undefined", perf.Sharpe, perf.Sortino, perf.Volatility)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, hlstats.ComputePerformance(tt.period))
+		})
+	}
+}
+
+func TestRatioJSON(t *testing.T) {
+	tests := []struct {
+		ratio hlstats.Ratio
+		want  string
+	}{
+		{ratio: 1.5, want: "1.5"},
+		{ratio: hlstats.Ratio(math.NaN()), want: "null"},
+		{ratio: hlstats.Ratio(math.Inf(1)), want: `"+Inf"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			data, err := json.Marshal(tt.ratio)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal(%v) = %s, %v; want %s", tt.ratio, data, err, tt.want)
+			}
+
+			var got hlstats.Ratio
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", data, err)
+			}
+			if got != tt.ratio && !(got.Undefined() && tt.ratio.Undefined()) {
+				t.Errorf("Unmarshal(%s) = %v, want %v", data, got, tt.ratio)
+			}
+		})
+	}
+}
+
+func TestPeriodPerformanceMetric(t *testing.T) {
+	perf := hlstats.PeriodPerformance{Return: 1, MaxDrawdown: 2, Volatility: 3, Sharpe: 4, Sortino: 5, PnLToVolume: 6}
+
+	for i, metric := range hlstats.PerformanceMetrics {
+		got, err := perf.Metric(metric)
+		if err != nil {
+			t.Fatalf("Metric(%q) error = %v", metric, err)
+		}
+		if got != float64(i+1) {
+			t.Errorf("Metric(%q) = %v, want %v", metric, got, i+1)
+		}
+	}
+	if _, err := perf.Metric("alpha"); err == nil {
+		t.Errorf("Metric(%q) error = nil", "alpha")
+	}
+}
+
+func TestVaultPerformancesSortByMetric(t *testing.T) {
+	data := hlstats.VaultPerformances{
+		{Name: "a", TVL: 1, Month: hlstats.PeriodPerformance{Return: 0.1, MaxDrawdown: 0.2, Sharpe: 1, Sortino: hlstats.Ratio(math.Inf(1))}, Week: hlstats.PeriodPerformance{Return: -0.1}},
+		{Name: "b", TVL: 3, Month: hlstats.PeriodPerformance{Return: 0.3, MaxDrawdown: 0.05, Sharpe: 0.5, Sortino: hlstats.Ratio(math.NaN())}, Week: hlstats.PeriodPerformance{Return: 0.2}},
+		{Name: "c", TVL: 2, IsHLP: true, Month: hlstats.PeriodPerformance{Return: -0.2, MaxDrawdown: 0.4, Sharpe: 2, Sortino: 3}},
+	}
+
+	names := func(data hlstats.VaultPerformances) []string {
+		var result []string
+		for _, item := range data {
+			result = append(result, item.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		period  string
+		metric  string
+		want    []string
+		wantErr bool
+	}{
+		{period: "month", metric: "return", want: []string{"b", "a", "c"}},
+		{period: "month", metric: "Drawdown", want: []string{"b", "a", "c"}},
+		{period: "month", metric: "sharpe", want: []string{"c", "a", "b"}},
+		// A Sortino ratio without losses ranks first, an undefined one last
+		{period: "month", metric: "sortino", want: []string{"a", "c", "b"}},
+		{period: "week", metric: "return", want: []string{"b", "c", "a"}},
+		{period: "year", metric: "return", wantErr: true},
+		{period: "month", metric: "alpha", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period+"/"+tt.metric, func(t *testing.T) {
+			got, err := data.SortByMetric(tt.period, tt.metric)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SortByMetric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(names(got), tt.want) {
+				t.Errorf("SortByMetric() = %v, want %v", names(got), tt.want)
+			}
+		})
+	}
+
+	got, err := data.SortBy("month", hlstats.SortKeys{{Field: "hlp"}, {Field: "tvl"}})
+	if err != nil {
+		t.Fatalf("SortBy() error = %v", err)
+	}
+	if want := []string{"c", "b", "a"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("SortBy() = %v, want %v", names(got), want)
+	}
+
+	// Undefined ratios also sort last in ascending order
+	got, err = data.SortBy("month", hlstats.SortKeys{{Field: "sortino", Order: hlstats.SortAsc}})
+	if err != nil {
+		t.Fatalf("SortBy() error = %v", err)
+	}
+	if want := []string{"c", "a", "b"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("SortBy(sortino:asc) = %v, want %v", names(got), want)
+	}
+}
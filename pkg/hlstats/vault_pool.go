@@ -0,0 +1,216 @@
+package hlstats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultVaultFilters select the vaults processed by the concurrent per-vault fetches when no
+// filters are given: open vaults with some TVL.
+func defaultVaultFilters() []VaultPredicate {
+	return []VaultPredicate{OpenOnly(), MinTVL(10)}
+}
+
+// selectVaults returns the vaults processed by the concurrent per-vault fetches, HLP vaults first,
+// then by TVL descending. The HLP parent vault, whose figures duplicate its children, is skipped.
+// The page is applied last, so that it selects exactly the requested number of vaults.
+func (c *Client) selectVaults(ctx context.Context, hlpOnly bool, page Page, filters []VaultPredicate) ([]Vault, error) {
+	if err := page.Validate(); err != nil {
+		return nil, err
+	}
+
+	vaults, err := c.FetchAllVault(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch vaults")
+	}
+
+	if len(filters) == 0 {
+		filters = defaultVaultFilters()
+	}
+	vaults = vaults.Filter(filters...).SortWithHLPPriority(false)
+
+	var selected []Vault
+	for _, vault := range vaults {
+		if c.network.IsHLPParent(vault.Data.Address) {
+			continue
+		}
+		if hlpOnly && !vault.IsHLP() {
+			continue
+		}
+		selected = append(selected, vault)
+	}
+
+	return Paginate(selected, page), nil
+}
+
+// fetchVaultsConcurrent calls fetch for every vault using a pool of workers and returns the results
+// in the order of vaults, so that the output does not depend on which fetch finishes first. Calls
+// are retried by the transport according to the retry policy, and progress, including retries, is
+// reported for every vault. If ctx is cancelled, in-flight fetches are aborted and the results
+// collected so far are returned together with the context error, described as a "<what> fetch".
+// If some vaults fail, the successful results are returned together with a *PartialFetchError.
+func fetchVaultsConcurrent[T any](ctx context.Context, c *Client, vaults []Vault, workers int, what string,
+	fetch func(context.Context, Vault) (T, error)) ([]T, error) {
+	start := time.Now()
+
+	// Set up worker pool
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(vaults) {
+		workers = len(vaults)
+	}
+
+	type vaultJob struct {
+		index int
+		vault Vault
+	}
+	type vaultResult struct {
+		index int
+		vault Vault
+		value T
+	}
+
+	// Channels for work distribution and result collection
+	vaultChan := make(chan vaultJob, len(vaults))
+	resultChan := make(chan vaultResult, len(vaults))
+	errorChan := make(chan *VaultFetchError, len(vaults))
+
+	// Start workers
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range vaultChan {
+				vault := job.vault
+				if ctx.Err() != nil {
+					return
+				}
+
+				c.reportProgress(ProgressEvent{
+					Type:    VaultStarted,
+					Address: vault.Data.Address,
+					Name:    vault.Data.Name,
+					Attempt: 1,
+					Total:   len(vaults),
+					Elapsed: time.Since(start),
+				})
+
+				// Retries are left to the transport, which reports them here
+				attempts := 1
+				fetchCtx := withRetryObserver(ctx, func(attempt int, err error) {
+					attempts = attempt
+					c.reportProgress(ProgressEvent{
+						Type:    VaultRetried,
+						Address: vault.Data.Address,
+						Name:    vault.Data.Name,
+						Attempt: attempt,
+						Total:   len(vaults),
+						Elapsed: time.Since(start),
+						Err:     err,
+					})
+				})
+
+				value, err := fetch(fetchCtx, vault)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					errorChan <- &VaultFetchError{
+						Address:    vault.Data.Address,
+						Name:       vault.Data.Name,
+						Attempts:   attempts,
+						StatusCode: statusCodeOf(err),
+						Err:        err,
+					}
+					continue
+				}
+
+				resultChan <- vaultResult{index: job.index, vault: vault, value: value}
+			}
+		}()
+	}
+
+	// Send work to workers
+	go func() {
+		defer close(vaultChan)
+		for i, vault := range vaults {
+			select {
+			case <-ctx.Done():
+				return
+			case vaultChan <- vaultJob{index: i, vault: vault}:
+			}
+		}
+	}()
+
+	// Wait for all workers to finish
+	go func() {
+		wg.Wait()
+		close(resultChan)
+		close(errorChan)
+	}()
+
+	// Collect results until both channels are drained
+	values := make([]T, len(vaults))
+	fetched := make([]bool, len(vaults))
+	var succeeded int
+	var fetchErrors VaultFetchErrors
+
+	for resultChan != nil || errorChan != nil {
+		select {
+		case item, ok := <-resultChan:
+			if !ok {
+				resultChan = nil
+				continue
+			}
+			values[item.index], fetched[item.index] = item.value, true
+			succeeded++
+			c.reportProgress(ProgressEvent{
+				Type:      VaultDone,
+				Address:   item.vault.Data.Address,
+				Name:      item.vault.Data.Name,
+				Completed: succeeded + len(fetchErrors),
+				Total:     len(vaults),
+				Elapsed:   time.Since(start),
+			})
+		case fetchErr, ok := <-errorChan:
+			if !ok {
+				errorChan = nil
+				continue
+			}
+			fetchErrors = append(fetchErrors, fetchErr)
+			c.reportProgress(ProgressEvent{
+				Type:      VaultFailed,
+				Address:   fetchErr.Address,
+				Name:      fetchErr.Name,
+				Attempt:   fetchErr.Attempts,
+				Completed: succeeded + len(fetchErrors),
+				Total:     len(vaults),
+				Elapsed:   time.Since(start),
+				Err:       fetchErr.Err,
+			})
+		}
+	}
+
+	result := make([]T, 0, succeeded)
+	for i, value := range values {
+		if fetched[i] {
+			result = append(result, value)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, errors.Wrapf(err, "%s fetch interrupted after %d/%d vaults", what, len(result), len(vaults))
+	}
+
+	// Return results even if some requests failed
+	if len(fetchErrors) > 0 {
+		return result, &PartialFetchError{Failures: fetchErrors, Total: len(vaults)}
+	}
+
+	return result, nil
+}
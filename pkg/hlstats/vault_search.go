@@ -0,0 +1,118 @@
+package hlstats
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// leaderQueryPrefix selects vaults by leader address in a vault query.
+const leaderQueryPrefix = "leader:"
+
+// ErrVaultNotFound is returned when a vault query matches no vault.
+var ErrVaultNotFound = errors.New("vault not found")
+
+var fullAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// AmbiguousVaultError is returned when a vault query that must identify a single vault matches several.
+type AmbiguousVaultError struct {
+	Query   string
+	Matches Vaults
+}
+
+func (e *AmbiguousVaultError) Error() string {
+	return fmt.Sprintf("vault query %q is ambiguous: it matches %d vaults", e.Query, len(e.Matches))
+}
+
+// Search returns the vaults matching a query, which is one of:
+//   - a full vault address, matched case-insensitively;
+//   - an address prefix starting with 0x;
+//   - "leader:" followed by a leader address or address prefix;
+//   - otherwise a case-insensitive substring of the vault name.
+func (data Vaults) Search(query string) Vaults {
+	query = strings.TrimSpace(query)
+	lower := strings.ToLower(query)
+
+	var match func(v Vault) bool
+	switch {
+	case lower == "":
+		return nil
+	case strings.HasPrefix(lower, leaderQueryPrefix):
+		leader := strings.TrimSpace(strings.TrimPrefix(lower, leaderQueryPrefix))
+		match = func(v Vault) bool {
+			return leader != "" && strings.HasPrefix(strings.ToLower(v.Data.Leader), leader)
+		}
+	case strings.HasPrefix(lower, "0x"):
+		match = func(v Vault) bool {
+			return strings.HasPrefix(strings.ToLower(v.Data.Address), lower)
+		}
+	default:
+		match = func(v Vault) bool {
+			return strings.Contains(strings.ToLower(v.Data.Name), lower)
+		}
+	}
+
+	var result Vaults
+	for _, vault := range data {
+		if match(vault) {
+			result = append(result, vault)
+		}
+	}
+
+	return result
+}
+
+// Resolve returns the single vault identified by a query (see Search). A full address or a name
+// that matches exactly wins over longer matches. A full address that is not listed resolves to a
+// vault with only its address set, so that unlisted vaults can still be queried. Otherwise an error
+// wrapping ErrVaultNotFound or an *AmbiguousVaultError is returned.
+func (data Vaults) Resolve(query string) (Vault, error) {
+	matches := data.Search(query)
+
+	if len(matches) > 1 {
+		query := strings.TrimSpace(query)
+		for _, vault := range matches {
+			if strings.EqualFold(vault.Data.Address, query) || strings.EqualFold(vault.Data.Name, query) {
+				return vault, nil
+			}
+		}
+		return Vault{}, &AmbiguousVaultError{Query: query, Matches: matches}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	if query := strings.TrimSpace(query); fullAddressPattern.MatchString(query) {
+		return Vault{Data: VaultSummary{Address: query}}, nil
+	}
+
+	return Vault{}, errors.Wrapf(ErrVaultNotFound, "no vault matches %q", query)
+}
+
+// SearchVaults returns the listed vaults matching a query (see Vaults.Search).
+func (c *Client) SearchVaults(ctx context.Context, query string) (Vaults, error) {
+	vaults, err := c.FetchAllVault(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch vaults")
+	}
+
+	return vaults.Search(query), nil
+}
+
+// ResolveVault returns the single listed vault identified by a query (see Vaults.Resolve).
+func (c *Client) ResolveVault(ctx context.Context, query string) (Vault, error) {
+	query = strings.TrimSpace(query)
+	vaults, err := c.FetchAllVault(ctx)
+	if err != nil {
+		// A full address does not need the listing
+		if fullAddressPattern.MatchString(query) {
+			return Vault{Data: VaultSummary{Address: query}}, nil
+		}
+		return Vault{}, errors.Wrap(err, "failed to fetch vaults")
+	}
+
+	return vaults.Resolve(query)
+}
@@ -0,0 +1,242 @@
+package hlstats
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// VaultPnL is the PnL of a vault per period.
+type VaultPnL struct {
+	Day     float64 `json:"day"`
+	Week    float64 `json:"week"`
+	Month   float64 `json:"month"`
+	AllTime float64 `json:"allTime"`
+}
+
+// VaultFigures are the TVL, volume and PnL of a vault or of a group of vaults.
+type VaultFigures struct {
+	TVL    float64     `json:"tvl"`
+	Volume VaultVolume `json:"volume"`
+	PnL    VaultPnL    `json:"pnl"`
+}
+
+// Add adds the figures of other to f.
+func (f *VaultFigures) Add(other VaultFigures) {
+	f.TVL += other.TVL
+
+	f.Volume.Day += other.Volume.Day
+	f.Volume.Week += other.Volume.Week
+	f.Volume.Month += other.Volume.Month
+	f.Volume.AllTime += other.Volume.AllTime
+	f.Volume.PerpDay += other.Volume.PerpDay
+	f.Volume.PerpWeek += other.Volume.PerpWeek
+	f.Volume.PerpMonth += other.Volume.PerpMonth
+	f.Volume.PerpAllTime += other.Volume.PerpAllTime
+	f.Volume.SpotDay += other.Volume.SpotDay
+	f.Volume.SpotWeek += other.Volume.SpotWeek
+	f.Volume.SpotMonth += other.Volume.SpotMonth
+	f.Volume.SpotAllTime += other.Volume.SpotAllTime
+
+	f.PnL.Day += other.PnL.Day
+	f.PnL.Week += other.PnL.Week
+	f.PnL.Month += other.PnL.Month
+	f.PnL.AllTime += other.PnL.AllTime
+}
+
+// VaultNode is a vault of a vault tree together with its child vaults.
+type VaultNode struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Leader  string `json:"leader"`
+	IsHLP   bool   `json:"isHLP"`
+	// Own holds the figures of the vault itself.
+	Own VaultFigures `json:"own"`
+	// Total holds the rolled-up figures of the vault. A parent vault allocates its capital to its
+	// children and its figures duplicate theirs, so its TVL, volume and PnL are the sums over its
+	// children. For a vault without children Total equals Own.
+	Total    VaultFigures `json:"total"`
+	Children []*VaultNode `json:"children,omitempty"`
+}
+
+// rollUp computes Total from Own, or from the children's totals if the vault has children.
+func (n *VaultNode) rollUp() {
+	if len(n.Children) == 0 {
+		n.Total = n.Own
+		return
+	}
+
+	n.Total = VaultFigures{}
+	for _, child := range n.Children {
+		child.rollUp()
+		n.Total.Add(child.Total)
+	}
+}
+
+// VaultTree is a list of root vaults, each with its child vaults.
+type VaultTree []*VaultNode
+
+// Total returns the sum of the rolled-up figures of the roots.
+func (t VaultTree) Total() VaultFigures {
+	var total VaultFigures
+	for _, node := range t {
+		total.Add(node.Total)
+	}
+
+	return total
+}
+
+// FetchVaultTree resolves the parent/child relationships of the given vaults from vaultDetails and
+// returns one tree per parent vault, with rolled-up figures. A vault given in roots that is a child is
+// replaced by its parent; a vault without relationship is returned as a tree on its own. If some
+// vaults fail to fetch, the tree is built from the remaining vaults and returned together with a
+// *PartialFetchError.
+func (c *Client) FetchVaultTree(ctx context.Context, roots []string, workers int) (VaultTree, error) {
+	vaults, err := c.FetchAllVault(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch vaults")
+	}
+	listed := make(map[string]Vault, len(vaults))
+	for _, vault := range vaults {
+		listed[strings.ToLower(vault.Data.Address)] = vault
+	}
+
+	details := make(map[string]VaultDetails)
+	attempted := make(map[string]bool)
+	var failures VaultFetchErrors
+	requested := 0
+
+	fetch := func(addresses []string) error {
+		var pending []Vault
+		for _, address := range addresses {
+			key := strings.ToLower(address)
+			if attempted[key] {
+				continue
+			}
+			attempted[key] = true
+
+			vault, ok := listed[key]
+			if !ok {
+				vault = Vault{Data: VaultSummary{Address: address}}
+			}
+			pending = append(pending, vault)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		requested += len(pending)
+
+		result, err := fetchVaultsConcurrent(ctx, c, pending, workers, "vault tree",
+			func(ctx context.Context, vault Vault) (VaultDetails, error) {
+				d, err := c.FetchVaultDetails(ctx, vault.Data.Address)
+				if err != nil {
+					return VaultDetails{}, err
+				}
+				// Key the details by the requested address
+				d.Address = vault.Data.Address
+				return d, nil
+			})
+		for _, d := range result {
+			details[strings.ToLower(d.Address)] = d
+		}
+		if partial, ok := AsPartialFetchError(err); ok {
+			failures = append(failures, partial.Failures...)
+			return nil
+		}
+
+		return err
+	}
+
+	// Resolve the parents of the given vaults, then the children of every parent
+	if err := fetch(roots); err != nil {
+		return nil, err
+	}
+	var parents []string
+	for _, address := range roots {
+		d, ok := details[strings.ToLower(address)]
+		if ok && d.Relationship.Type == RelationshipChild && d.Relationship.ParentAddress != "" {
+			parents = append(parents, d.Relationship.ParentAddress)
+		} else {
+			parents = append(parents, address)
+		}
+	}
+	if err := fetch(parents); err != nil {
+		return nil, err
+	}
+	var children []string
+	for _, address := range parents {
+		if d, ok := details[strings.ToLower(address)]; ok {
+			children = append(children, d.Relationship.ChildAddresses...)
+		}
+	}
+	if err := fetch(children); err != nil {
+		return nil, err
+	}
+
+	// Build one tree per parent
+	var tree VaultTree
+	seen := make(map[string]bool)
+	for _, address := range parents {
+		key := strings.ToLower(address)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		d, ok := details[key]
+		if !ok {
+			continue
+		}
+		node := c.newVaultNode(listed, address, d)
+		for _, child := range d.Relationship.ChildAddresses {
+			node.Children = append(node.Children, c.newVaultNode(listed, child, details[strings.ToLower(child)]))
+		}
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].Own.TVL > node.Children[j].Own.TVL
+		})
+		node.rollUp()
+		tree = append(tree, node)
+	}
+	sort.SliceStable(tree, func(i, j int) bool {
+		return tree[i].Total.TVL > tree[j].Total.TVL
+	})
+
+	if len(failures) > 0 {
+		return tree, &PartialFetchError{Failures: failures, Total: requested}
+	}
+
+	return tree, nil
+}
+
+// newVaultNode builds a node from the vault listing and the vault's details, which may be empty if
+// they could not be fetched.
+func (c *Client) newVaultNode(listed map[string]Vault, address string, d VaultDetails) *VaultNode {
+	vault, isListed := listed[strings.ToLower(address)]
+
+	node := &VaultNode{
+		Address: address,
+		Name:    d.Name,
+		Leader:  d.Leader,
+		IsHLP:   c.network.IsHLPParent(address) || c.network.IsHLPParent(d.Relationship.ParentAddress),
+		Own: VaultFigures{
+			TVL:    d.Portfolio.AllTime.AccountValueHistory.Last(),
+			Volume: d.Portfolio.Volume(),
+			PnL: VaultPnL{
+				Day:     d.Portfolio.Day.PnLHistory.Last(),
+				Week:    d.Portfolio.Week.PnLHistory.Last(),
+				Month:   d.Portfolio.Month.PnLHistory.Last(),
+				AllTime: d.Portfolio.AllTime.PnLHistory.Last(),
+			},
+		},
+	}
+	if isListed {
+		node.Name = vault.Data.Name
+		node.Leader = vault.Data.Leader
+		node.IsHLP = node.IsHLP || vault.IsHLP()
+		node.Own.TVL = vault.Data.TVL
+	}
+
+	return node
+}
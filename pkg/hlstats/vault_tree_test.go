@@ -0,0 +1,73 @@
+package hlstats_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestFetchVaultTreeRollUp(t *testing.T) {
+	// A parent allocating to two children, each with volumes split between perp and spot
+	details := map[string]string{
+		"0xp":  `"relationship":{"type":"parent","data":{"childAddresses":["0xc1","0xc2"]}},"portfolio":[["day",{"vlm":"10"}],["perpDay",{"vlm":"10"}]]`,
+		"0xc1": `"relationship":{"type":"child","data":{"parentAddress":"0xp"}},"portfolio":[["day",{"vlm":"100"}],["perpDay",{"vlm":"60"}]]`,
+		"0xc2": `"relationship":{"type":"child","data":{"parentAddress":"0xp"}},"portfolio":[["day",{"vlm":"50"}],["perpDay",{"vlm":"20"}]]`,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vaults", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[
+			{"summary":{"name":"Parent","vaultAddress":"0xp","tvl":"300"}},
+			{"summary":{"name":"Child 1","vaultAddress":"0xc1","tvl":"200"}},
+			{"summary":{"name":"Child 2","vaultAddress":"0xc2","tvl":"100"}}
+		]`)
+	})
+	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
+		var payload hlstats.VaultVolumeRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || details[payload.Address] == "" {
+			http.Error(w, "unknown vault", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"vaultAddress":%q,%s}`, payload.Address, details[payload.Address])
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithVaultsURL(server.URL+"/vaults"),
+		hlstats.WithLimiter(nil),
+	)
+	tree, err := client.FetchVaultTree(context.Background(), []string{"0xc1"}, 2)
+	if err != nil {
+		t.Fatalf("FetchVaultTree() error = %v", err)
+	}
+	if len(tree) != 1 || tree[0].Address != "0xp" || len(tree[0].Children) != 2 {
+		t.Fatalf("FetchVaultTree() = %+v, want 0xp with two children", tree)
+	}
+
+	total := tree[0].Total
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "TVL", got: total.TVL, want: 300},
+		// The parent's own volume duplicates its children's and is not added to theirs
+		{name: "Day", got: total.Volume.Day, want: 150},
+		{name: "PerpDay", got: total.Volume.PerpDay, want: 80},
+		{name: "SpotDay", got: total.Volume.SpotDay, want: 70},
+		{name: "tree SpotDay", got: tree.Total().Volume.SpotDay, want: 70},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !approxEqual(tt.got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
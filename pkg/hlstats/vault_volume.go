@@ -0,0 +1,210 @@
+package hlstats
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// VaultVolume is the trading volume of a vault per period, in USD. The Perp fields only count perpetuals.
+type VaultVolume struct {
+	Day     float64 `json:"day"`
+	Week    float64 `json:"week"`
+	Month   float64 `json:"month"`
+	AllTime float64 `json:"allTime"`
+
+	PerpDay     float64 `json:"perpDay"`
+	PerpWeek    float64 `json:"perpWeek"`
+	PerpMonth   float64 `json:"perpMonth"`
+	PerpAllTime float64 `json:"perpAllTime"`
+
+	// The spot volumes are not reported by the API; they are derived as total minus perp.
+	SpotDay     float64 `json:"spotDay"`
+	SpotWeek    float64 `json:"spotWeek"`
+	SpotMonth   float64 `json:"spotMonth"`
+	SpotAllTime float64 `json:"spotAllTime"`
+}
+
+// Market selects the volumes of a market: all (total), perp or spot.
+type Market string
+
+const (
+	MarketAll  Market = "all"
+	MarketPerp Market = "perp"
+	MarketSpot Market = "spot"
+)
+
+// Markets lists all supported markets.
+var Markets = []Market{MarketAll, MarketPerp, MarketSpot}
+
+// ParseMarket parses a case-insensitive market name. An empty name selects MarketAll.
+func ParseMarket(s string) (Market, error) {
+	m := Market(strings.ToLower(strings.TrimSpace(s)))
+	if m == "" {
+		return MarketAll, nil
+	}
+	for _, supported := range Markets {
+		if m == supported {
+			return m, nil
+		}
+	}
+
+	return "", errors.Errorf("invalid market '%s'. Valid options: all, perp, spot", s)
+}
+
+// MarketVolume is the volume of a vault in a single market per period.
+type MarketVolume struct {
+	Day     float64 `json:"day"`
+	Week    float64 `json:"week"`
+	Month   float64 `json:"month"`
+	AllTime float64 `json:"allTime"`
+}
+
+// ForMarket returns the volumes of the given market. An unknown market selects the totals.
+func (v VaultVolume) ForMarket(market Market) MarketVolume {
+	switch market {
+	case MarketPerp:
+		return MarketVolume{Day: v.PerpDay, Week: v.PerpWeek, Month: v.PerpMonth, AllTime: v.PerpAllTime}
+	case MarketSpot:
+		return MarketVolume{Day: v.SpotDay, Week: v.SpotWeek, Month: v.SpotMonth, AllTime: v.SpotAllTime}
+	default:
+		return MarketVolume{Day: v.Day, Week: v.Week, Month: v.Month, AllTime: v.AllTime}
+	}
+}
+
+// Period returns the volume of the named period (see PerformancePeriods).
+func (v MarketVolume) Period(period string) (float64, error) {
+	switch strings.ToLower(period) {
+	case "day":
+		return v.Day, nil
+	case "week":
+		return v.Week, nil
+	case "month":
+		return v.Month, nil
+	case "all-time", "alltime":
+		return v.AllTime, nil
+	default:
+		return 0, errors.Errorf("invalid period '%s'. Valid options: %s", period, strings.Join(PerformancePeriods, ", "))
+	}
+}
+
+// deriveSpot sets the spot volumes from the total and perp volumes. Rounding in the API can make
+// the difference slightly negative, so it is floored at zero.
+func (v *VaultVolume) deriveSpot() {
+	v.SpotDay = math.Max(v.Day-v.PerpDay, 0)
+	v.SpotWeek = math.Max(v.Week-v.PerpWeek, 0)
+	v.SpotMonth = math.Max(v.Month-v.PerpMonth, 0)
+	v.SpotAllTime = math.Max(v.AllTime-v.PerpAllTime, 0)
+}
+
+func (v *VaultVolume) UnmarshalJSON(data []byte) error {
+	// The object form is the encoding of VaultVolume itself, e.g. in saved JSON output
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		type plain VaultVolume
+		return json.Unmarshal(trimmed, (*plain)(v))
+	}
+
+	var tmp [][]interface{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal to []interface{}")
+	}
+
+	for _, d := range tmp {
+		if len(d) < 2 {
+			return errors.New("invalid vault volume")
+		}
+
+		var tmpMap map[string]interface{}
+		jsb, _ := json.Marshal(d[1])
+		if err := json.Unmarshal(jsb, &tmpMap); err != nil {
+			return errors.Wrap(err, "failed to unmarshal to map")
+		}
+
+		switch d[0].(string) {
+		case "day":
+			v.Day, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "week":
+			v.Week, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "month":
+			v.Month, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "allTime":
+			v.AllTime, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "perpDay":
+			v.PerpDay, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "perpWeek":
+			v.PerpWeek, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "perpMonth":
+			v.PerpMonth, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		case "perpAllTime":
+			v.PerpAllTime, _ = strconv.ParseFloat(tmpMap["vlm"].(string), 64)
+		default:
+			return errors.New("invalid vault volume")
+		}
+	}
+	v.deriveSpot()
+
+	return nil
+}
+
+// VaultVolumeRequest is the vaultDetails request sent to the info endpoint.
+type VaultVolumeRequest struct {
+	Type    string `json:"type"`
+	Address string `json:"vaultAddress"`
+	User    string `json:"user,omitempty"`
+}
+
+// VaultVolumeResponse is the vaultDetails response of the info endpoint.
+type VaultVolumeResponse struct {
+	Portfolio VaultPortfolio `json:"portfolio"`
+}
+
+// VaultVolumeInfo is the volume of a vault together with its listing details.
+type VaultVolumeInfo struct {
+	Address string      `json:"address"`
+	Name    string      `json:"name"`
+	Parent  string      `json:"parent,omitempty"`
+	Volume  VaultVolume `json:"volume"`
+	TVL     float64     `json:"tvl"`
+	IsHLP   bool        `json:"isHLP"`
+}
+
+// VaultVolumesInfo is a list of vault volumes.
+type VaultVolumesInfo []VaultVolumeInfo
+
+// VaultVolumeSortFields returns the fields vault volumes can be sorted by, with the volume periods
+// (day, week, month, all-time) taken from the given market. Numbers and hlp sort descending by
+// default, text ascending.
+func VaultVolumeSortFields(market Market) SortFields[VaultVolumeInfo] {
+	return SortFields[VaultVolumeInfo]{
+		"hlp":      boolField(func(v VaultVolumeInfo) bool { return v.IsHLP }),
+		"name":     textField(func(v VaultVolumeInfo) string { return v.Name }),
+		"address":  textField(func(v VaultVolumeInfo) string { return v.Address }),
+		"tvl":      numberField(func(v VaultVolumeInfo) float64 { return v.TVL }),
+		"day":      numberField(func(v VaultVolumeInfo) float64 { return v.Volume.ForMarket(market).Day }),
+		"week":     numberField(func(v VaultVolumeInfo) float64 { return v.Volume.ForMarket(market).Week }),
+		"month":    numberField(func(v VaultVolumeInfo) float64 { return v.Volume.ForMarket(market).Month }),
+		"all-time": numberField(func(v VaultVolumeInfo) float64 { return v.Volume.ForMarket(market).AllTime }),
+	}
+}
+
+// SortBy returns the vaults stably sorted by keys, with volumes of the given market (see
+// VaultVolumeSortFields).
+func (data VaultVolumesInfo) SortBy(keys SortKeys, market Market) (VaultVolumesInfo, error) {
+	return VaultVolumeSortFields(market).Sort(data, keys)
+}
+
+// SortByField sorts the vaults by the total volume of the given period (day, week, month or
+// all-time), or by tvl, descending.
+func (data VaultVolumesInfo) SortByField(field string) (VaultVolumesInfo, error) {
+	return data.SortByMarketField(field, MarketAll)
+}
+
+// SortByMarketField sorts the vaults by the volume of the given market and period (day, week, month
+// or all-time), or by tvl, descending.
+func (data VaultVolumesInfo) SortByMarketField(field string, market Market) (VaultVolumesInfo, error) {
+	return data.SortBy(SortKeys{{Field: strings.ToLower(field), Order: SortDesc}}, market)
+}
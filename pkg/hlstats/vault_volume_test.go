@@ -0,0 +1,267 @@
+package hlstats_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestVaultVolumeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    hlstats.VaultVolume
+		wantErr bool
+	}{
+		{
+			name: "portfolio",
+			data: `[["day",{"vlm":"100.0"}],["week",{"vlm":"700.0"}],["month",{"vlm":"3000.0"}],["allTime",{"vlm":"50000.0"}],
+				["perpDay",{"vlm":"60"}],["perpWeek",{"vlm":"700.0"}],["perpMonth",{"vlm":"3000.5"}],["perpAllTime",{"vlm":"40000"}]]`,
+			want: hlstats.VaultVolume{
+				Day: 100, Week: 700, Month: 3000, AllTime: 50000,
+				PerpDay: 60, PerpWeek: 700, PerpMonth: 3000.5, PerpAllTime: 40000,
+				SpotDay: 40, SpotAllTime: 10000,
+			},
+		},
+		{
+			name: "object",
+			data: `{"day":1,"week":2,"month":3,"allTime":4,"perpDay":1,"spotWeek":2}`,
+			want: hlstats.VaultVolume{Day: 1, Week: 2, Month: 3, AllTime: 4, PerpDay: 1, SpotWeek: 2},
+		},
+		{name: "unknown period", data: `[["hour",{"vlm":"1"}]]`, wantErr: true},
+		{name: "short entry", data: `[["day"]]`, wantErr: true},
+		{name: "not a portfolio", data: `"day"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hlstats.VaultVolume
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultVolumeRoundTrip(t *testing.T) {
+	want := hlstats.VaultVolume{Day: 1, Week: 2, Month: 3, AllTime: 4, PerpDay: 0.5, SpotDay: 0.5}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got hlstats.VaultVolume
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Unmarshal(Marshal()) = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseMarket(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    hlstats.Market
+		wantErr bool
+	}{
+		{s: "", want: hlstats.MarketAll},
+		{s: "all", want: hlstats.MarketAll},
+		{s: " Perp ", want: hlstats.MarketPerp},
+		{s: "SPOT", want: hlstats.MarketSpot},
+		{s: "options", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := hlstats.ParseMarket(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMarket() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMarket() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarketVolumePeriod(t *testing.T) {
+	volume := hlstats.VaultVolume{
+		Day: 1, Week: 2, Month: 3, AllTime: 4,
+		PerpDay: 10, PerpWeek: 20, PerpMonth: 30, PerpAllTime: 40,
+		SpotDay: 100, SpotWeek: 200, SpotMonth: 300, SpotAllTime: 400,
+	}
+
+	tests := []struct {
+		market  hlstats.Market
+		period  string
+		want    float64
+		wantErr bool
+	}{
+		{market: hlstats.MarketAll, period: "day", want: 1},
+		{market: hlstats.MarketAll, period: "All-Time", want: 4},
+		{market: hlstats.MarketPerp, period: "week", want: 20},
+		{market: hlstats.MarketSpot, period: "month", want: 300},
+		{market: hlstats.MarketSpot, period: "alltime", want: 400},
+		{market: "unknown", period: "day", want: 1},
+		{market: hlstats.MarketAll, period: "year", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.market)+"/"+tt.period, func(t *testing.T) {
+			got, err := volume.ForMarket(tt.market).Period(tt.period)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Period() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Period() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultVolumesInfoSortBy(t *testing.T) {
+	data := hlstats.VaultVolumesInfo{
+		{Name: "a", TVL: 3, Volume: hlstats.VaultVolume{Week: 10, PerpWeek: 9, SpotWeek: 1}},
+		{Name: "b", TVL: 1, IsHLP: true, Volume: hlstats.VaultVolume{Week: 20, PerpWeek: 5, SpotWeek: 15}},
+		{Name: "c", TVL: 2, Volume: hlstats.VaultVolume{Week: 5, PerpWeek: 5}},
+	}
+
+	names := func(data hlstats.VaultVolumesInfo) []string {
+		var result []string
+		for _, item := range data {
+			result = append(result, item.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		keys    string
+		market  hlstats.Market
+		want    []string
+		wantErr bool
+	}{
+		{keys: "tvl", market: hlstats.MarketAll, want: []string{"a", "c", "b"}},
+		{keys: "week", market: hlstats.MarketAll, want: []string{"b", "a", "c"}},
+		{keys: "week", market: hlstats.MarketPerp, want: []string{"a", "b", "c"}},
+		{keys: "week:asc", market: hlstats.MarketSpot, want: []string{"c", "a", "b"}},
+		{keys: "hlp,name:desc", market: hlstats.MarketAll, want: []string{"b", "c", "a"}},
+		{keys: "apr", market: hlstats.MarketAll, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keys+"/"+string(tt.market), func(t *testing.T) {
+			keys, err := hlstats.ParseSortKeys(tt.keys)
+			if err != nil {
+				t.Fatalf("ParseSortKeys() error = %v", err)
+			}
+
+			got, err := data.SortBy(keys, tt.market)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SortBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(names(got), tt.want) {
+				t.Errorf("SortBy() = %v, want %v", names(got), tt.want)
+			}
+		})
+	}
+
+	got, err := data.SortByMarketField("Week", hlstats.MarketSpot)
+	if err != nil {
+		t.Fatalf("SortByMarketField() error = %v", err)
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("SortByMarketField() = %v, want %v", names(got), want)
+	}
+}
+
+func TestFetchAllVaultVolumesConcurrentOrder(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vaults", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[
+			{"summary":{"name":"Third","vaultAddress":"0x3","tvl":"100"}},
+			{"summary":{"name":"First","vaultAddress":"0x1","tvl":"300"}},
+			{"summary":{"name":"Second","vaultAddress":"0x2","tvl":"200"}}
+		]`)
+	})
+	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
+		var payload hlstats.VaultVolumeRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// The first vault of the listing finishes last
+		if payload.Address == "0x1" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		io.WriteString(w, `{"vaultAddress":"`+payload.Address+`","portfolio":[["week",{"vlm":"100.0"}]]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithVaultsURL(server.URL+"/vaults"),
+		hlstats.WithLimiter(nil),
+	)
+	got, err := client.FetchAllVaultVolumesConcurrent(context.Background(), false, hlstats.Page{}, 3)
+	if err != nil {
+		t.Fatalf("FetchAllVaultVolumesConcurrent() error = %v", err)
+	}
+
+	var addresses []string
+	for _, v := range got {
+		addresses = append(addresses, v.Address)
+	}
+	if want := []string{"0x1", "0x2", "0x3"}; !reflect.DeepEqual(addresses, want) {
+		t.Errorf("FetchAllVaultVolumesConcurrent() = %v, want listing order %v", addresses, want)
+	}
+}
+
+func TestFetchAllVaultVolumesConcurrentRetries(t *testing.T) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vaults", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[{"summary":{"name":"Busy","vaultAddress":"0x1","tvl":"100"}}]`)
+	})
+	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := hlstats.NewClient(server.URL, server.URL+"/info",
+		hlstats.WithVaultsURL(server.URL+"/vaults"),
+		hlstats.WithLimiter(nil),
+		hlstats.WithRetryPolicy(hlstats.RetryPolicy{
+			MaxAttempts:       3,
+			BaseDelay:         time.Millisecond,
+			RetryableStatuses: []int{http.StatusTooManyRequests},
+		}),
+	)
+	_, err := client.FetchAllVaultVolumesConcurrent(context.Background(), false, hlstats.Page{}, 1)
+
+	// The retry policy alone bounds the attempts of a rate limited vault
+	partial, ok := hlstats.AsPartialFetchError(err)
+	if !ok || len(partial.Failures) != 1 {
+		t.Fatalf("FetchAllVaultVolumesConcurrent() error = %v, want one failed vault", err)
+	}
+	if got := partial.Failures[0].Attempts; got != 3 {
+		t.Errorf("Attempts = %d, want 3", got)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("sent %d vault requests, want 3", got)
+	}
+}
@@ -0,0 +1,243 @@
+package snapshot
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// TVLChange is the change of the TVL of a vault between two snapshots.
+type TVLChange struct {
+	Address string  `json:"address"`
+	Name    string  `json:"name"`
+	From    float64 `json:"from"`
+	To      float64 `json:"to"`
+	Change  float64 `json:"change"`
+	// Percent is the change relative to From as a fraction (0.1 is 10%), or 0 if From is 0.
+	Percent float64 `json:"percent"`
+}
+
+// LeaderChange is a vault whose leader changed between two snapshots.
+type LeaderChange struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// RankMove is the change of the volume rank of a vault between two snapshots. Ranks start at 1 and
+// only count the vaults present in both snapshots; Move is positive if the vault moved up.
+type RankMove struct {
+	Address string  `json:"address"`
+	Name    string  `json:"name"`
+	From    int     `json:"from"`
+	To      int     `json:"to"`
+	Move    int     `json:"move"`
+	Volume  float64 `json:"volume"`
+}
+
+// VaultDiff is the difference between two vaults snapshots and, optionally, two vault-volumes
+// snapshots. The TVL and rank lists are ordered by the size of the change, largest first.
+type VaultDiff struct {
+	Network string    `json:"network"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	// New lists the vaults only listed at To, Removed the vaults only listed at From, with their
+	// records at From, and Closed the vaults that were open at From and closed at To.
+	New     []VaultRecord `json:"new"`
+	Removed []VaultRecord `json:"removed"`
+	Closed  []VaultRecord `json:"closed"`
+	// TVLByAmount and TVLByPercent both list the TVL changes of the vaults listed at both times.
+	TVLByAmount  []TVLChange    `json:"tvlByAmount"`
+	TVLByPercent []TVLChange    `json:"tvlByPercent"`
+	Leaders      []LeaderChange `json:"leaders"`
+	// VolumePeriod and VolumeMarket select the volumes VolumeRanks are computed from; all three are
+	// empty unless CompareVolumes was called.
+	VolumePeriod string         `json:"volumePeriod,omitempty"`
+	VolumeMarket hlstats.Market `json:"volumeMarket,omitempty"`
+	VolumeRanks  []RankMove     `json:"volumeRanks,omitempty"`
+}
+
+// index maps the lower-cased addresses of records to the records.
+func index(records []VaultRecord) map[string]VaultRecord {
+	result := make(map[string]VaultRecord, len(records))
+	for _, record := range records {
+		result[strings.ToLower(record.Address)] = record
+	}
+
+	return result
+}
+
+// CompareVaults compares two vaults snapshots. Vaults with a TVL below minTVL at both times are
+// left out of the TVL changes, where their relative changes would only be noise.
+func CompareVaults(from, to Snapshot, minTVL float64) VaultDiff {
+	diff := VaultDiff{
+		Network:      to.Network,
+		From:         from.Time,
+		To:           to.Time,
+		New:          []VaultRecord{},
+		Removed:      []VaultRecord{},
+		Closed:       []VaultRecord{},
+		TVLByAmount:  []TVLChange{},
+		TVLByPercent: []TVLChange{},
+		Leaders:      []LeaderChange{},
+	}
+
+	before := index(from.Vaults)
+	after := index(to.Vaults)
+	for _, record := range from.Vaults {
+		if _, ok := after[strings.ToLower(record.Address)]; !ok {
+			diff.Removed = append(diff.Removed, record)
+		}
+	}
+	for _, record := range to.Vaults {
+		old, ok := before[strings.ToLower(record.Address)]
+		if !ok {
+			diff.New = append(diff.New, record)
+			continue
+		}
+
+		if !old.Closed && record.Closed {
+			diff.Closed = append(diff.Closed, record)
+		}
+		if old.Leader != "" && record.Leader != "" && !strings.EqualFold(old.Leader, record.Leader) {
+			diff.Leaders = append(diff.Leaders, LeaderChange{
+				Address: record.Address,
+				Name:    record.Name,
+				From:    old.Leader,
+				To:      record.Leader,
+			})
+		}
+		if (old.TVL >= minTVL || record.TVL >= minTVL) && record.TVL != old.TVL {
+			change := TVLChange{
+				Address: record.Address,
+				Name:    record.Name,
+				From:    old.TVL,
+				To:      record.TVL,
+				Change:  record.TVL - old.TVL,
+			}
+			if old.TVL != 0 {
+				change.Percent = change.Change / old.TVL
+			}
+			diff.TVLByAmount = append(diff.TVLByAmount, change)
+		}
+	}
+
+	sort.SliceStable(diff.New, func(i, j int) bool {
+		return diff.New[i].TVL > diff.New[j].TVL
+	})
+	sort.SliceStable(diff.Removed, func(i, j int) bool {
+		return diff.Removed[i].TVL > diff.Removed[j].TVL
+	})
+	diff.TVLByPercent = append(diff.TVLByPercent, diff.TVLByAmount...)
+	sort.SliceStable(diff.TVLByAmount, func(i, j int) bool {
+		return math.Abs(diff.TVLByAmount[i].Change) > math.Abs(diff.TVLByAmount[j].Change)
+	})
+	sort.SliceStable(diff.TVLByPercent, func(i, j int) bool {
+		return math.Abs(diff.TVLByPercent[i].Percent) > math.Abs(diff.TVLByPercent[j].Percent)
+	})
+
+	return diff
+}
+
+// CompareVolumes sets the volume rank movers of the diff from two vault-volumes snapshots, ranking
+// the vaults present in both by their volume of the given period (see hlstats.PerformancePeriods)
+// and market.
+func (d *VaultDiff) CompareVolumes(from, to Snapshot, period string, market hlstats.Market) error {
+	if _, err := (hlstats.MarketVolume{}).Period(period); err != nil {
+		return err
+	}
+	volume := func(record VaultRecord) float64 {
+		if record.Volume == nil {
+			return 0
+		}
+		v, _ := record.Volume.ForMarket(market).Period(period)
+		return v
+	}
+
+	before, after := index(from.Vaults), index(to.Vaults)
+	ranks := func(records map[string]VaultRecord, other map[string]VaultRecord) map[string]int {
+		type entry struct {
+			address string
+			volume  float64
+		}
+		var entries []entry
+		for address, record := range records {
+			if _, ok := other[address]; ok {
+				entries = append(entries, entry{address, volume(record)})
+			}
+		}
+		// Ties are broken by address so that equal volumes rank the same way in both snapshots
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].volume != entries[j].volume {
+				return entries[i].volume > entries[j].volume
+			}
+			return entries[i].address < entries[j].address
+		})
+
+		result := make(map[string]int, len(entries))
+		for i, e := range entries {
+			result[e.address] = i + 1
+		}
+		return result
+	}
+	fromRanks, toRanks := ranks(before, after), ranks(after, before)
+
+	d.VolumePeriod = strings.ToLower(period)
+	d.VolumeMarket = market
+	d.VolumeRanks = []RankMove{}
+	for address, rank := range toRanks {
+		move := fromRanks[address] - rank
+		if move == 0 {
+			continue
+		}
+		record := after[address]
+		d.VolumeRanks = append(d.VolumeRanks, RankMove{
+			Address: record.Address,
+			Name:    record.Name,
+			From:    fromRanks[address],
+			To:      rank,
+			Move:    move,
+			Volume:  volume(record),
+		})
+	}
+	sort.Slice(d.VolumeRanks, func(i, j int) bool {
+		a, b := d.VolumeRanks[i], d.VolumeRanks[j]
+		if abs(a.Move) != abs(b.Move) {
+			return abs(a.Move) > abs(b.Move)
+		}
+		return a.To < b.To
+	})
+
+	return nil
+}
+
+// Top returns the diff with each list cut to its first n entries; n <= 0 keeps all entries.
+func (d VaultDiff) Top(n int) VaultDiff {
+	if n <= 0 {
+		return d
+	}
+
+	d.New = d.New[:min(n, len(d.New))]
+	d.Removed = d.Removed[:min(n, len(d.Removed))]
+	d.Closed = d.Closed[:min(n, len(d.Closed))]
+	d.TVLByAmount = d.TVLByAmount[:min(n, len(d.TVLByAmount))]
+	d.TVLByPercent = d.TVLByPercent[:min(n, len(d.TVLByPercent))]
+	d.Leaders = d.Leaders[:min(n, len(d.Leaders))]
+	if d.VolumeRanks != nil {
+		d.VolumeRanks = d.VolumeRanks[:min(n, len(d.VolumeRanks))]
+	}
+
+	return d
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,125 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// addresses returns the addresses of records, in order.
+func addresses(records []VaultRecord) []string {
+	result := []string{}
+	for _, record := range records {
+		result = append(result, record.Address)
+	}
+
+	return result
+}
+
+func TestCompareVaults(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	vaults := func(at time.Time, records ...VaultRecord) Snapshot {
+		return Snapshot{Time: at, Network: "mainnet", Kind: KindVaults, Vaults: records}
+	}
+	from := vaults(t0,
+		VaultRecord{Address: "0xa", Leader: "0x1", TVL: 100000},
+		VaultRecord{Address: "0xb", Leader: "0x2", TVL: 50000},
+		VaultRecord{Address: "0xc", Leader: "0x3", TVL: 200000},
+		VaultRecord{Address: "0xd", Leader: "0x4", TVL: 500},
+		VaultRecord{Address: "0xe", Leader: "0x5", TVL: 80000},
+	)
+	to := vaults(t0.Add(24*time.Hour),
+		VaultRecord{Address: "0xA", Leader: "0x9", TVL: 150000},
+		VaultRecord{Address: "0xb", Leader: "0x2", TVL: 40000, Closed: true},
+		VaultRecord{Address: "0xd", Leader: "0x4", TVL: 1000},
+		VaultRecord{Address: "0xf", Leader: "0x6", TVL: 10000},
+		VaultRecord{Address: "0xg", Leader: "0x7", TVL: 30000},
+	)
+	diff := CompareVaults(from, to, 10000)
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{name: "new", got: addresses(diff.New), want: []string{"0xg", "0xf"}},
+		{name: "removed", got: addresses(diff.Removed), want: []string{"0xc", "0xe"}},
+		{name: "closed", got: addresses(diff.Closed), want: []string{"0xb"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+
+	// Vault 0xd stays below minTVL and 0xa matches case-insensitively
+	var tvl []string
+	for _, change := range diff.TVLByAmount {
+		tvl = append(tvl, change.Address)
+	}
+	if want := []string{"0xA", "0xb"}; !reflect.DeepEqual(tvl, want) {
+		t.Errorf("TVLByAmount = %v, want %v", tvl, want)
+	}
+	if len(diff.Leaders) != 1 || diff.Leaders[0].From != "0x1" || diff.Leaders[0].To != "0x9" {
+		t.Errorf("Leaders = %+v, want 0x1 -> 0x9", diff.Leaders)
+	}
+	if top := diff.Top(1); len(top.Removed) != 1 || top.Removed[0].Address != "0xc" {
+		t.Errorf("Top(1).Removed = %v, want [0xc]", addresses(top.Removed))
+	}
+}
+
+func TestCompareVolumes(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	record := func(address string, day, perpDay float64) VaultRecord {
+		return VaultRecord{Address: address, Volume: &hlstats.VaultVolume{Day: day, PerpDay: perpDay}}
+	}
+	// 0xd is only in from and 0xe only in to, so neither is ranked
+	from := Snapshot{Time: t0, Kind: KindVaultVolumes, Vaults: []VaultRecord{
+		record("0xa", 10, 3), record("0xb", 20, 2), record("0xc", 30, 1), record("0xd", 50, 0),
+	}}
+	to := Snapshot{Time: t0.Add(24 * time.Hour), Kind: KindVaultVolumes, Vaults: []VaultRecord{
+		record("0xA", 40, 3), record("0xb", 20, 2), record("0xc", 30, 1), record("0xe", 100, 0),
+		{Address: "0xf"},
+	}}
+
+	tests := []struct {
+		name    string
+		period  string
+		market  hlstats.Market
+		want    []RankMove
+		wantErr bool
+	}{
+		{
+			name:   "all markets",
+			period: "Day",
+			market: hlstats.MarketAll,
+			want: []RankMove{
+				{Address: "0xA", From: 3, To: 1, Move: 2, Volume: 40},
+				{Address: "0xc", From: 1, To: 2, Move: -1, Volume: 30},
+				{Address: "0xb", From: 2, To: 3, Move: -1, Volume: 20},
+			},
+		},
+		{name: "unchanged perp ranks", period: "day", market: hlstats.MarketPerp, want: []RankMove{}},
+		{name: "invalid period", period: "fortnight", market: hlstats.MarketAll, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := CompareVaults(from, to, 0)
+			err := diff.CompareVolumes(from, to, tt.period, tt.market)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompareVolumes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(diff.VolumeRanks, tt.want) {
+				t.Errorf("VolumeRanks = %+v, want %+v", diff.VolumeRanks, tt.want)
+			}
+		})
+	}
+}
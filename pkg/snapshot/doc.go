@@ -0,0 +1,10 @@
+// Package snapshot stores timestamped results of hlstats fetches on disk, so that the evolution of
+// vaults and leaderboards can be queried over time.
+//
+// A store is a directory holding one append-only NDJSON file per network and snapshot kind:
+//
+//	store, err := snapshot.Open(dir)
+//	vaults, err := client.FetchAllVault(ctx)
+//	err = store.Save(snapshot.NewVaults("mainnet", time.Now(), vaults))
+//	points, err := store.VaultHistory("mainnet", address, since, time.Time{})
+package snapshot
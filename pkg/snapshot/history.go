@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// VaultPoint is the state of a vault at the time of a snapshot. Snapshots of both vault kinds taken
+// at the same time are merged into one point: APR and Closed come from the vaults listing, Volume
+// from the vault volumes and is nil if no vault-volumes snapshot was taken at that time.
+type VaultPoint struct {
+	Time   time.Time            `json:"time"`
+	Name   string               `json:"name"`
+	TVL    float64              `json:"tvl"`
+	APR    float64              `json:"apr"`
+	Closed bool                 `json:"closed"`
+	Volume *hlstats.VaultVolume `json:"volume,omitempty"`
+}
+
+// UserPoint is the leaderboard position of a user at the time of a snapshot. Snapshots of both
+// leaderboards taken at the same time are merged into one point; a rank of 0 means the user is not
+// on that leaderboard.
+type UserPoint struct {
+	Time       time.Time `json:"time"`
+	VolumeRank int       `json:"volumeRank"`
+	Volume     float64   `json:"volume"`
+	TradeRank  int       `json:"tradeRank"`
+	Trades     float64   `json:"trades"`
+}
+
+// VaultHistory returns the states of a vault recorded in [since, until] (see Load), oldest first.
+func (s *Store) VaultHistory(network, address string, since, until time.Time) ([]VaultPoint, error) {
+	// Decoded times may differ in location only, so points are keyed by instant
+	points := make(map[int64]*VaultPoint)
+	point := func(at time.Time) *VaultPoint {
+		if p, ok := points[at.UnixNano()]; ok {
+			return p
+		}
+		p := &VaultPoint{Time: at}
+		points[at.UnixNano()] = p
+		return p
+	}
+
+	for _, kind := range []Kind{KindVaults, KindVaultVolumes} {
+		snaps, err := s.Load(network, kind, since, until)
+		if err != nil {
+			return nil, err
+		}
+		for _, snap := range snaps {
+			for _, record := range snap.Vaults {
+				if !strings.EqualFold(record.Address, address) {
+					continue
+				}
+
+				p := point(snap.Time)
+				p.Name = record.Name
+				p.TVL = record.TVL
+				if kind == KindVaults {
+					p.APR = record.APR
+					p.Closed = record.Closed
+				} else {
+					p.Volume = record.Volume
+				}
+				break
+			}
+		}
+	}
+
+	result := make([]VaultPoint, 0, len(points))
+	for _, p := range points {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// UserHistory returns the leaderboard positions of a user recorded in [since, until] (see Load),
+// oldest first.
+func (s *Store) UserHistory(network, user string, since, until time.Time) ([]UserPoint, error) {
+	points := make(map[int64]*UserPoint)
+	for _, kind := range []Kind{KindLargestUsers, KindTradeCounts} {
+		snaps, err := s.Load(network, kind, since, until)
+		if err != nil {
+			return nil, err
+		}
+		for _, snap := range snaps {
+			for _, record := range snap.Users {
+				if !strings.EqualFold(record.User, user) {
+					continue
+				}
+
+				p, ok := points[snap.Time.UnixNano()]
+				if !ok {
+					p = &UserPoint{Time: snap.Time}
+					points[snap.Time.UnixNano()] = p
+				}
+				if kind == KindLargestUsers {
+					p.VolumeRank, p.Volume = record.Rank, record.Value
+				} else {
+					p.TradeRank, p.Trades = record.Rank, record.Value
+				}
+				break
+			}
+		}
+	}
+
+	result := make([]UserPoint, 0, len(points))
+	for _, p := range points {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
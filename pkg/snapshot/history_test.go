@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// historyStore returns a store with vaults, vault-volumes and leaderboard snapshots taken at t0,
+// t0+1h and t0+2h.
+func historyStore(t *testing.T, t0 time.Time) *Store {
+	t.Helper()
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t1, t2 := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	snaps := []Snapshot{
+		{Time: t0, Network: "mainnet", Kind: KindVaults, Vaults: []VaultRecord{
+			{Address: "0xA", Name: "Alpha", TVL: 100, APR: 0.1},
+			{Address: "0xb", Name: "Beta", TVL: 50},
+		}},
+		{Time: t1, Network: "mainnet", Kind: KindVaults, Vaults: []VaultRecord{
+			{Address: "0xa", Name: "Alpha v2", TVL: 120, APR: 0.2, Closed: true},
+		}},
+		{Time: t1, Network: "mainnet", Kind: KindVaultVolumes, Vaults: []VaultRecord{
+			{Address: "0xa", Name: "Alpha v2", TVL: 121, Volume: &hlstats.VaultVolume{Day: 5}},
+		}},
+		{Time: t2, Network: "mainnet", Kind: KindVaultVolumes, Vaults: []VaultRecord{
+			{Address: "0xa", Name: "Alpha v2", TVL: 130, Volume: &hlstats.VaultVolume{Day: 7}},
+		}},
+		{Time: t1, Network: "testnet", Kind: KindVaults, Vaults: []VaultRecord{
+			{Address: "0xa", Name: "Test Alpha", TVL: 1},
+		}},
+		NewLargestUsers("mainnet", t0, hlstats.USDVolumeByUsers{{Name: "0xu", Value: 100}, {Name: "0xv", Value: 50}}),
+		NewLargestUsers("mainnet", t1, hlstats.USDVolumeByUsers{{Name: "0xv", Value: 90}, {Name: "0xU", Value: 80}}),
+		NewTradeCounts("mainnet", t1, hlstats.LargestTradeCounts{{Name: "0xu", Value: 50}}),
+		NewTradeCounts("mainnet", t2, hlstats.LargestTradeCounts{{Name: "0xv", Value: 20}}),
+	}
+	for _, snap := range snaps {
+		if err := store.Save(snap); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	return store
+}
+
+func TestVaultHistory(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	t1, t2 := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	store := historyStore(t, t0)
+
+	// At t1 both kinds merge into one point: APR and Closed from the listing, the volume and the
+	// later TVL from the vault volumes.
+	p0 := VaultPoint{Time: t0, Name: "Alpha", TVL: 100, APR: 0.1}
+	p1 := VaultPoint{Time: t1, Name: "Alpha v2", TVL: 121, APR: 0.2, Closed: true, Volume: &hlstats.VaultVolume{Day: 5}}
+	p2 := VaultPoint{Time: t2, Name: "Alpha v2", TVL: 130, Volume: &hlstats.VaultVolume{Day: 7}}
+
+	tests := []struct {
+		name         string
+		network      string
+		address      string
+		since, until time.Time
+		want         []VaultPoint
+	}{
+		{name: "all", network: "mainnet", address: "0xa", want: []VaultPoint{p0, p1, p2}},
+		{name: "since", network: "mainnet", address: "0xA", since: t1, want: []VaultPoint{p1, p2}},
+		{name: "until", network: "mainnet", address: "0xa", until: t0, want: []VaultPoint{p0}},
+		{name: "other network", network: "testnet", address: "0xa", want: []VaultPoint{{Time: t1, Name: "Test Alpha", TVL: 1}}},
+		{name: "unknown vault", network: "mainnet", address: "0xc", want: []VaultPoint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.VaultHistory(tt.network, tt.address, tt.since, tt.until)
+			if err != nil {
+				t.Fatalf("VaultHistory() error = %v", err)
+			}
+			// Decoded times may differ in location only
+			for i := range got {
+				if i < len(tt.want) && got[i].Time.Equal(tt.want[i].Time) {
+					got[i].Time = tt.want[i].Time
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VaultHistory() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserHistory(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	t1, t2 := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	store := historyStore(t, t0)
+
+	tests := []struct {
+		name         string
+		user         string
+		since, until time.Time
+		want         []UserPoint
+	}{
+		{
+			name: "both leaderboards",
+			user: "0xu",
+			want: []UserPoint{
+				{Time: t0, VolumeRank: 1, Volume: 100},
+				{Time: t1, VolumeRank: 2, Volume: 80, TradeRank: 1, Trades: 50},
+			},
+		},
+		{
+			name:  "since",
+			user:  "0xV",
+			since: t1,
+			want: []UserPoint{
+				{Time: t1, VolumeRank: 1, Volume: 90},
+				{Time: t2, TradeRank: 1, Trades: 20},
+			},
+		},
+		{name: "unknown user", user: "0xw", want: []UserPoint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.UserHistory("mainnet", tt.user, tt.since, tt.until)
+			if err != nil {
+				t.Fatalf("UserHistory() error = %v", err)
+			}
+			for i := range got {
+				if i < len(tt.want) && got[i].Time.Equal(tt.want[i].Time) {
+					got[i].Time = tt.want[i].Time
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserHistory() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// RankStatus marks a leaderboard entry that entered or left the leaderboard since a previous
+// snapshot of it.
+type RankStatus string
+
+const (
+	// RankNew marks a user that was not on the previous leaderboard.
+	RankNew RankStatus = "new"
+	// RankDropped marks a user of the previous leaderboard that is no longer on it.
+	RankDropped RankStatus = "dropped"
+)
+
+// RankedUser is a leaderboard entry, compared with a previous snapshot of the leaderboard by
+// CompareRanks. Ranks start at 1; a rank of 0 means the user is not on that leaderboard. Value is the
+// USD volume or the number of trades.
+type RankedUser struct {
+	Name          string     `json:"name"`
+	Rank          int        `json:"rank,omitempty"`
+	Value         float64    `json:"value"`
+	PreviousRank  int        `json:"previousRank,omitempty"`
+	PreviousValue float64    `json:"previousValue,omitempty"`
+	Status        RankStatus `json:"status,omitempty"`
+}
+
+// RankChange returns the number of places the user moved up, negative if down, or 0 if the user is
+// not on both leaderboards.
+func (u RankedUser) RankChange() int {
+	if u.Rank == 0 || u.PreviousRank == 0 {
+		return 0
+	}
+
+	return u.PreviousRank - u.Rank
+}
+
+// ValueChange returns the change of the value since the previous leaderboard.
+func (u RankedUser) ValueChange() float64 {
+	return u.Value - u.PreviousValue
+}
+
+// RankedUsers is a leaderboard: the users on it by rank, followed by the users that dropped out of
+// it by previous rank.
+type RankedUsers []RankedUser
+
+// Ranks returns the entries of a largest-users or trade-counts snapshot by rank.
+func Ranks(current Snapshot) RankedUsers {
+	result := make(RankedUsers, 0, len(current.Users))
+	for _, user := range current.Users {
+		result = append(result, RankedUser{Name: user.User, Rank: user.Rank, Value: user.Value})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Rank < result[j].Rank
+	})
+
+	return result
+}
+
+// CompareRanks returns the entries of the current leaderboard compared with those of a previous
+// snapshot of the same kind, followed by the users that dropped out of it.
+func CompareRanks(previous, current Snapshot) RankedUsers {
+	before := make(map[string]UserRecord, len(previous.Users))
+	for _, user := range previous.Users {
+		before[strings.ToLower(user.User)] = user
+	}
+
+	result := Ranks(current)
+	for i := range result {
+		key := strings.ToLower(result[i].Name)
+		if old, ok := before[key]; ok {
+			result[i].PreviousRank = old.Rank
+			result[i].PreviousValue = old.Value
+			delete(before, key)
+		} else {
+			result[i].Status = RankNew
+		}
+	}
+
+	dropped := make(RankedUsers, 0, len(before))
+	for _, old := range before {
+		dropped = append(dropped, RankedUser{
+			Name:          old.User,
+			PreviousRank:  old.Rank,
+			PreviousValue: old.Value,
+			Status:        RankDropped,
+		})
+	}
+	sort.Slice(dropped, func(i, j int) bool {
+		return dropped[i].PreviousRank < dropped[j].PreviousRank
+	})
+
+	return append(result, dropped...)
+}
+
+// Paginate selects a page of the leaderboard, together with the users that dropped out of the same
+// page of the previous leaderboard. Ranks are kept, so they stay absolute.
+func (data RankedUsers) Paginate(page hlstats.Page) RankedUsers {
+	var ranked, dropped RankedUsers
+	previous := 0
+	for _, user := range data {
+		if user.Status == RankDropped {
+			dropped = append(dropped, user)
+		} else {
+			ranked = append(ranked, user)
+		}
+		if user.PreviousRank > 0 {
+			previous++
+		}
+	}
+
+	result := append(RankedUsers{}, hlstats.Paginate(ranked, page)...)
+	if len(dropped) == 0 {
+		return result
+	}
+
+	start, end := page.Bounds(previous)
+	for _, user := range dropped {
+		if user.PreviousRank > start && user.PreviousRank <= end {
+			result = append(result, user)
+		}
+	}
+
+	return result
+}
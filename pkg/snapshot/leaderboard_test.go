@@ -0,0 +1,99 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+// names returns the names of users, in order.
+func names(users RankedUsers) []string {
+	result := []string{}
+	for _, user := range users {
+		result = append(result, user.Name)
+	}
+
+	return result
+}
+
+// leaderboards returns a previous and a current leaderboard: 0xB overtakes 0xa, 0xe enters at
+// rank 3, 0xd keeps its rank with a lower volume and 0xc drops out from rank 3.
+func leaderboards() (previous, current Snapshot) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	previous = NewLargestUsers("mainnet", t0, hlstats.USDVolumeByUsers{
+		{Name: "0xc", Value: 60}, {Name: "0xa", Value: 100}, {Name: "0xd", Value: 40}, {Name: "0xb", Value: 80},
+	})
+	current = NewLargestUsers("mainnet", t0.Add(24*time.Hour), hlstats.USDVolumeByUsers{
+		{Name: "0xa", Value: 90}, {Name: "0xd", Value: 10}, {Name: "0xB", Value: 120}, {Name: "0xe", Value: 70},
+	})
+
+	return previous, current
+}
+
+func TestCompareRanks(t *testing.T) {
+	previous, current := leaderboards()
+	got := CompareRanks(previous, current)
+
+	want := RankedUsers{
+		{Name: "0xB", Rank: 1, Value: 120, PreviousRank: 2, PreviousValue: 80},
+		{Name: "0xa", Rank: 2, Value: 90, PreviousRank: 1, PreviousValue: 100},
+		{Name: "0xe", Rank: 3, Value: 70, Status: RankNew},
+		{Name: "0xd", Rank: 4, Value: 10, PreviousRank: 4, PreviousValue: 40},
+		{Name: "0xc", PreviousRank: 3, PreviousValue: 60, Status: RankDropped},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CompareRanks() = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name        string
+		user        RankedUser
+		rankChange  int
+		valueChange float64
+	}{
+		{name: "moved up", user: got[0], rankChange: 1, valueChange: 40},
+		{name: "moved down", user: got[1], rankChange: -1, valueChange: -10},
+		{name: "new", user: got[2], rankChange: 0, valueChange: 70},
+		{name: "kept its rank", user: got[3], rankChange: 0, valueChange: -30},
+		{name: "dropped", user: got[4], rankChange: 0, valueChange: -60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if change := tt.user.RankChange(); change != tt.rankChange {
+				t.Errorf("RankChange() = %d, want %d", change, tt.rankChange)
+			}
+			if change := tt.user.ValueChange(); change != tt.valueChange {
+				t.Errorf("ValueChange() = %v, want %v", change, tt.valueChange)
+			}
+		})
+	}
+}
+
+func TestRankedUsersPaginate(t *testing.T) {
+	previous, current := leaderboards()
+	compared := CompareRanks(previous, current)
+
+	tests := []struct {
+		name string
+		data RankedUsers
+		page hlstats.Page
+		want []string
+	}{
+		{name: "all", data: compared, page: hlstats.FirstN(0), want: []string{"0xB", "0xa", "0xe", "0xd", "0xc"}},
+		{name: "first page", data: compared, page: hlstats.FirstN(2), want: []string{"0xB", "0xa"}},
+		// 0xc dropped from rank 3, which is on the second page of the previous leaderboard
+		{name: "second page", data: compared, page: hlstats.Page{Offset: 2, Limit: 2}, want: []string{"0xe", "0xd", "0xc"}},
+		{name: "bottom", data: compared, page: hlstats.Page{Limit: 1, Bottom: true}, want: []string{"0xd"}},
+		{name: "without a previous leaderboard", data: Ranks(current), page: hlstats.Page{Offset: 1, Limit: 2}, want: []string{"0xa", "0xe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(tt.data.Paginate(tt.page)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate(%+v) = %v, want %v", tt.page, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the snapshot format written by Save.
+const Version = 1
+
+// Kind identifies the fetch a snapshot records.
+type Kind string
+
+const (
+	// KindVaults records the vaults listing (FetchAllVault).
+	KindVaults Kind = "vaults"
+	// KindVaultVolumes records the vault volumes (FetchAllVaultVolumesConcurrent).
+	KindVaultVolumes Kind = "vault-volumes"
+	// KindLargestUsers records the leaderboard by USD volume (FetchLargestUsers).
+	KindLargestUsers Kind = "largest-users"
+	// KindTradeCounts records the leaderboard by trade count (FetchLargestTradeCounts).
+	KindTradeCounts Kind = "trade-counts"
+)
+
+// Kinds lists all snapshot kinds.
+var Kinds = []Kind{KindVaults, KindVaultVolumes, KindLargestUsers, KindTradeCounts}
+
+// ParseKind parses a case-insensitive snapshot kind.
+func ParseKind(s string) (Kind, error) {
+	kind := Kind(strings.ToLower(strings.TrimSpace(s)))
+	if err := kind.Validate(); err != nil {
+		return "", err
+	}
+
+	return kind, nil
+}
+
+// Validate returns an error if the kind is not one of Kinds.
+func (k Kind) Validate() error {
+	for _, kind := range Kinds {
+		if k == kind {
+			return nil
+		}
+	}
+
+	names := make([]string, 0, len(Kinds))
+	for _, kind := range Kinds {
+		names = append(names, string(kind))
+	}
+	return errors.Errorf("invalid snapshot kind '%s'. Valid options: %s", k, strings.Join(names, ", "))
+}
+
+// VaultRecord is a vault as recorded in a vaults or vault-volumes snapshot. Volume is only set in
+// vault-volumes snapshots, APR, Leader and Closed only in vaults snapshots.
+type VaultRecord struct {
+	Address string               `json:"address"`
+	Name    string               `json:"name"`
+	Leader  string               `json:"leader,omitempty"`
+	Parent  string               `json:"parent,omitempty"`
+	TVL     float64              `json:"tvl"`
+	APR     float64              `json:"apr,omitempty"`
+	Closed  bool                 `json:"closed,omitempty"`
+	IsHLP   bool                 `json:"isHLP,omitempty"`
+	Volume  *hlstats.VaultVolume `json:"volume,omitempty"`
+}
+
+// Vault returns the record as a listed vault, e.g. to resolve vault queries against a snapshot.
+func (r VaultRecord) Vault() hlstats.Vault {
+	return hlstats.Vault{
+		Data: hlstats.VaultSummary{
+			Name:    r.Name,
+			Address: r.Address,
+			Leader:  r.Leader,
+			TVL:     r.TVL,
+			Closed:  r.Closed,
+		},
+		APR: r.APR,
+		HLP: r.IsHLP,
+	}
+}
+
+// UserRecord is a leaderboard entry as recorded in a largest-users or trade-counts snapshot. Rank
+// starts at 1; Value is the USD volume or the number of trades.
+type UserRecord struct {
+	User  string  `json:"user"`
+	Rank  int     `json:"rank"`
+	Value float64 `json:"value"`
+}
+
+// Snapshot is the timestamped result of one fetch. Vault kinds set Vaults, leaderboard kinds Users.
+type Snapshot struct {
+	Version int       `json:"version"`
+	Time    time.Time `json:"time"`
+	Network string    `json:"network"`
+	Kind    Kind      `json:"kind"`
+	// Partial is set if some vaults failed to fetch and are missing from the snapshot.
+	Partial bool          `json:"partial,omitempty"`
+	Vaults  []VaultRecord `json:"vaults,omitempty"`
+	Users   []UserRecord  `json:"users,omitempty"`
+}
+
+// Info summarises a snapshot.
+type Info struct {
+	Time    time.Time `json:"time"`
+	Network string    `json:"network"`
+	Kind    Kind      `json:"kind"`
+	Records int       `json:"records"`
+	Partial bool      `json:"partial"`
+}
+
+// Info summarises the snapshot.
+func (s Snapshot) Info() Info {
+	return Info{
+		Time:    s.Time,
+		Network: s.Network,
+		Kind:    s.Kind,
+		Records: len(s.Vaults) + len(s.Users),
+		Partial: s.Partial,
+	}
+}
+
+// ListedVaults returns the vaults of the snapshot as listed vaults.
+func (s Snapshot) ListedVaults() hlstats.Vaults {
+	result := make(hlstats.Vaults, 0, len(s.Vaults))
+	for _, record := range s.Vaults {
+		result = append(result, record.Vault())
+	}
+
+	return result
+}
+
+// NewVaults records the vaults listing.
+func NewVaults(network string, at time.Time, vaults hlstats.Vaults) Snapshot {
+	snap := Snapshot{Time: at, Network: network, Kind: KindVaults}
+	for _, vault := range vaults {
+		snap.Vaults = append(snap.Vaults, VaultRecord{
+			Address: vault.Data.Address,
+			Name:    vault.Data.Name,
+			Leader:  vault.Data.Leader,
+			Parent:  vault.Data.Relationship.ParentAddress,
+			TVL:     vault.Data.TVL,
+			APR:     vault.APR,
+			Closed:  vault.Data.Closed,
+			IsHLP:   vault.IsHLP(),
+		})
+	}
+
+	return snap
+}
+
+// NewVaultVolumes records the vault volumes; partial marks a fetch in which some vaults failed.
+func NewVaultVolumes(network string, at time.Time, volumes hlstats.VaultVolumesInfo, partial bool) Snapshot {
+	snap := Snapshot{Time: at, Network: network, Kind: KindVaultVolumes, Partial: partial}
+	for _, info := range volumes {
+		volume := info.Volume
+		snap.Vaults = append(snap.Vaults, VaultRecord{
+			Address: info.Address,
+			Name:    info.Name,
+			Parent:  info.Parent,
+			TVL:     info.TVL,
+			IsHLP:   info.IsHLP,
+			Volume:  &volume,
+		})
+	}
+
+	return snap
+}
+
+// NewLargestUsers records the leaderboard by USD volume.
+func NewLargestUsers(network string, at time.Time, users hlstats.USDVolumeByUsers) Snapshot {
+	snap := Snapshot{Time: at, Network: network, Kind: KindLargestUsers}
+	for _, user := range users {
+		snap.Users = append(snap.Users, UserRecord{User: user.Name, Value: user.Value})
+	}
+	rank(snap.Users)
+
+	return snap
+}
+
+// NewTradeCounts records the leaderboard by trade count.
+func NewTradeCounts(network string, at time.Time, users hlstats.LargestTradeCounts) Snapshot {
+	snap := Snapshot{Time: at, Network: network, Kind: KindTradeCounts}
+	for _, user := range users {
+		snap.Users = append(snap.Users, UserRecord{User: user.Name, Value: float64(user.Value)})
+	}
+	rank(snap.Users)
+
+	return snap
+}
+
+// rank sorts a leaderboard by value, largest first, and numbers its entries from 1.
+func rank(users []UserRecord) {
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Value > users[j].Value
+	})
+	for i := range users {
+		users[i].Rank = i + 1
+	}
+}
@@ -0,0 +1,248 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fileExt is the extension of the snapshot files; each file holds one JSON snapshot per line.
+const fileExt = ".ndjson"
+
+// Store is a directory of snapshot files, one per network and kind, to which snapshots are appended.
+// Appends and reads are serialised with an advisory file lock, so several processes can share a store.
+type Store struct {
+	dir string
+}
+
+// Open opens the store in dir, creating the directory if needed.
+func Open(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("snapshot store directory is not set")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot store %s", dir)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// path returns the file holding the snapshots of a network and kind.
+func (s *Store) path(network string, kind Kind) string {
+	return filepath.Join(s.dir, sanitize(network), string(kind)+fileExt)
+}
+
+// Save appends a snapshot to the store.
+func (s *Store) Save(snap Snapshot) error {
+	if err := snap.Kind.Validate(); err != nil {
+		return err
+	}
+	if snap.Time.IsZero() {
+		snap.Time = time.Now()
+	}
+	snap.Version = Version
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode snapshot")
+	}
+	data = append(data, '\n')
+
+	path := s.path(snap.Network, snap.Kind)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create snapshot directory for %s", snap.Network)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open snapshot file %s", path)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return errors.Wrapf(err, "failed to lock snapshot file %s", path)
+	}
+	defer unlockFile(f)
+
+	end, err := truncatePartial(f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to repair snapshot file %s", path)
+	}
+	if _, err := f.WriteAt(data, end); err != nil {
+		return errors.Wrapf(err, "failed to write snapshot file %s", path)
+	}
+
+	return nil
+}
+
+// truncatePartial removes a snapshot truncated by an interrupted write from the end of f, so that
+// the next snapshot starts on a line of its own, and returns the resulting size of f.
+func truncatePartial(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	// Find the end of the last complete line
+	size := info.Size()
+	end := size
+	buf := make([]byte, 4096)
+	for end > 0 {
+		n := min(int64(len(buf)), end)
+		if _, err := f.ReadAt(buf[:n], end-n); err != nil {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end += int64(i) + 1 - n
+			break
+		}
+		end -= n
+	}
+
+	if end == size {
+		return size, nil
+	}
+	return end, f.Truncate(end)
+}
+
+// Load returns the snapshots of a network and kind taken in [since, until], oldest first. A zero
+// since or until leaves that end of the range open. A snapshot truncated by an interrupted write
+// at the end of the file is ignored, and removed by the next Save.
+func (s *Store) Load(network string, kind Kind, since, until time.Time) ([]Snapshot, error) {
+	if err := kind.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := s.path(network, kind)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open snapshot file %s", path)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return nil, errors.Wrapf(err, "failed to lock snapshot file %s", path)
+	}
+	defer unlockFile(f)
+
+	var result []Snapshot
+	decoder := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var snap Snapshot
+		err := decoder.Decode(&snap)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read snapshot file %s", path)
+		}
+
+		if !since.IsZero() && snap.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && snap.Time.After(until) {
+			continue
+		}
+		result = append(result, snap)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// Latest returns the latest snapshot of a network and kind taken at or before at (any time if at is
+// zero), and false if there is none.
+func (s *Store) Latest(network string, kind Kind, at time.Time) (Snapshot, bool, error) {
+	snaps, err := s.Load(network, kind, time.Time{}, at)
+	if err != nil || len(snaps) == 0 {
+		return Snapshot{}, false, err
+	}
+
+	return snaps[len(snaps)-1], true, nil
+}
+
+// Nearest returns the snapshot of a network and kind taken closest to at, before or after it, and
+// false if there is none. Of two equally close snapshots, the earlier one is returned.
+func (s *Store) Nearest(network string, kind Kind, at time.Time) (Snapshot, bool, error) {
+	snaps, err := s.Load(network, kind, time.Time{}, time.Time{})
+	if err != nil || len(snaps) == 0 {
+		return Snapshot{}, false, err
+	}
+
+	nearest := snaps[0]
+	for _, snap := range snaps[1:] {
+		if snap.Time.Sub(at).Abs() < nearest.Time.Sub(at).Abs() {
+			nearest = snap
+		}
+	}
+
+	return nearest, true, nil
+}
+
+// List summarises the snapshots stored for a network, or for every network if network is empty.
+func (s *Store) List(network string) ([]Info, error) {
+	networks := []string{network}
+	if network == "" {
+		entries, err := os.ReadDir(s.dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read snapshot store %s", s.dir)
+		}
+		networks = nil
+		for _, entry := range entries {
+			if entry.IsDir() {
+				networks = append(networks, entry.Name())
+			}
+		}
+	}
+
+	var result []Info
+	for _, network := range networks {
+		for _, kind := range Kinds {
+			snaps, err := s.Load(network, kind, time.Time{}, time.Time{})
+			if err != nil {
+				return nil, err
+			}
+			for _, snap := range snaps {
+				result = append(result, snap.Info())
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// sanitize turns a network name into a directory name.
+func sanitize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "default"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LampardNguyen234/hyperliquid-stats/pkg/hlstats"
+)
+
+func TestStoreSaveAfterTruncatedWrite(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	save := func(at time.Time, value float64) {
+		t.Helper()
+		snap := NewLargestUsers("mainnet", at, hlstats.USDVolumeByUsers{{Name: "0xabc", Value: value}})
+		if err := store.Save(snap); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	save(t0, 1)
+	save(t0.Add(time.Hour), 2)
+
+	// Interrupt the write of the second snapshot
+	path := store.path("mainnet", KindLargestUsers)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-10); err != nil {
+		t.Fatal(err)
+	}
+	snaps, err := store.Load("mainnet", KindLargestUsers, time.Time{}, time.Time{})
+	if err != nil || len(snaps) != 1 {
+		t.Fatalf("Load() after truncation = %d snapshots, %v, want 1", len(snaps), err)
+	}
+
+	save(t0.Add(2*time.Hour), 3)
+	snaps, err = store.Load("mainnet", KindLargestUsers, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	if len(snaps) != 2 || !snaps[0].Time.Equal(t0) || !snaps[1].Time.Equal(t0.Add(2*time.Hour)) {
+		t.Errorf("Load() = %+v, want the first and the last snapshot", snaps)
+	}
+}
+
+func TestTruncatePartial(t *testing.T) {
+	// Longer than a read chunk
+	long := strings.Repeat("x", 5000)
+
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{name: "empty", contents: "", want: ""},
+		{name: "complete", contents: "{}\n{}\n", want: "{}\n{}\n"},
+		{name: "partial", contents: "{}\n{\"kind\":", want: "{}\n"},
+		{name: "only partial", contents: "{\"kind\":", want: ""},
+		{name: "long lines", contents: long + "\n" + long, want: long + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.CreateTemp(t.TempDir(), "snapshots")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			if _, err := f.WriteString(tt.contents); err != nil {
+				t.Fatal(err)
+			}
+
+			end, err := truncatePartial(f)
+			if err != nil {
+				t.Fatalf("truncatePartial() error = %v", err)
+			}
+			data, err := os.ReadFile(f.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want || end != int64(len(tt.want)) {
+				t.Errorf("truncatePartial() = %d, %q, want %d, %q", end, data, len(tt.want), tt.want)
+			}
+		})
+	}
+}